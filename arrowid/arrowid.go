@@ -0,0 +1,96 @@
+// Package arrowid converts between slices of gouuidv6.UUID and Apache Arrow
+// arrays, as FixedSizeBinary(16) or the canonical arrow.uuid extension type,
+// so ID columns can be moved in and out of Arrow (and Flight, Parquet etc.)
+// with a single copy or none.
+package arrowid
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/bitutil"
+	"github.com/apache/arrow-go/v18/arrow/extensions"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// Type is the storage type of UUID arrays.
+var Type = &arrow.FixedSizeBinaryType{ByteWidth: 16}
+
+// NewFixedSizeBinary returns a FixedSizeBinary(16) array of ids.  The array
+// shares the memory of ids without copying, so ids must not be modified
+// while it is in use.  If valid is not nil it must be the same length as
+// ids, and elements where it is false are null.
+func NewFixedSizeBinary(ids []gouuidv6.UUID, valid []bool) *array.FixedSizeBinary {
+
+	if valid != nil && len(valid) != len(ids) {
+		panic(fmt.Errorf("arrowid: %d validity flags for %d UUIDs", len(valid), len(ids)))
+	}
+
+	var bitmap *memory.Buffer
+	nulls := 0
+	if valid != nil {
+		b := make([]byte, bitutil.BytesForBits(int64(len(valid))))
+		for i, v := range valid {
+			if v {
+				bitutil.SetBit(b, i)
+			} else {
+				nulls++
+			}
+		}
+		bitmap = memory.NewBufferBytes(b)
+	}
+
+	var data []byte
+	if len(ids) > 0 {
+		data = unsafe.Slice(&ids[0][0], len(ids)*16)
+	}
+
+	d := array.NewData(Type, len(ids), []*memory.Buffer{bitmap, memory.NewBufferBytes(data)}, nil, nulls, 0)
+	defer d.Release()
+	return array.NewFixedSizeBinaryData(d)
+}
+
+// NewExtensionArray returns ids as an array of the arrow.uuid extension
+// type, with storage from NewFixedSizeBinary.
+func NewExtensionArray(ids []gouuidv6.UUID, valid []bool) array.ExtensionArray {
+	storage := NewFixedSizeBinary(ids, valid)
+	defer storage.Release()
+	return array.NewExtensionArrayWithStorage(extensions.NewUUIDType(), storage).(array.ExtensionArray)
+}
+
+// FromArray returns the UUIDs in a, which must be a FixedSizeBinary(16)
+// array or an extension array with that storage (such as arrow.uuid), copied
+// in one go.  If a has nulls valid says which elements are not null, and the
+// null elements are the zero UUID; otherwise valid is nil.
+func FromArray(a arrow.Array) (ids []gouuidv6.UUID, valid []bool, err error) {
+
+	if ext, ok := a.(array.ExtensionArray); ok {
+		a = ext.Storage()
+	}
+	fsb, ok := a.(*array.FixedSizeBinary)
+	if !ok || !arrow.TypeEqual(fsb.DataType(), Type) {
+		return nil, nil, fmt.Errorf("arrowid: cannot read UUIDs from %v array", a.DataType())
+	}
+
+	ids = make([]gouuidv6.UUID, fsb.Len())
+	if len(ids) == 0 {
+		return ids, nil, nil
+	}
+	off := fsb.Offset() * 16
+	copy(unsafe.Slice(&ids[0][0], len(ids)*16), fsb.Data().Buffers()[1].Bytes()[off:])
+
+	if fsb.NullN() > 0 {
+		valid = make([]bool, len(ids))
+		for i := range ids {
+			if valid[i] = fsb.IsValid(i); !valid[i] {
+				ids[i] = gouuidv6.UUID{}
+			}
+		}
+	}
+
+	return ids, valid, nil
+}
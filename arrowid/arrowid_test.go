@@ -0,0 +1,68 @@
+package arrowid
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestRoundTrip(t *testing.T) {
+
+	ids := gouuidv6.NewBatchParallel(100, 1)
+	valid := make([]bool, len(ids))
+	for i := range valid {
+		valid[i] = i%7 != 0
+	}
+
+	a := NewFixedSizeBinary(ids, valid)
+	defer a.Release()
+	if a.Len() != 100 || a.NullN() != 15 {
+		t.Fatalf("Expected 100 elements with 15 nulls, got %d and %d", a.Len(), a.NullN())
+	}
+	if string(a.Value(1)) != string(ids[1][:]) {
+		t.Fatalf("Expected %v at 1, got %x", ids[1], a.Value(1))
+	}
+
+	e := NewExtensionArray(ids, valid)
+	defer e.Release()
+	if e.ExtensionType().ExtensionName() != "arrow.uuid" {
+		t.Fatalf("Unexpected extension type %v", e.ExtensionType())
+	}
+
+	for _, arr := range []arrow.Array{a, e} {
+		got, gotValid, err := FromArray(arr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(ids) || len(gotValid) != len(ids) {
+			t.Fatalf("Expected %d UUIDs and validity flags, got %d and %d", len(ids), len(got), len(gotValid))
+		}
+		for i := range ids {
+			want := ids[i]
+			if !valid[i] {
+				want = gouuidv6.UUID{}
+			}
+			if got[i] != want || gotValid[i] != valid[i] {
+				t.Fatalf("Expected %v at %d, got %v", want, i, got[i])
+			}
+		}
+	}
+
+	// slices of arrays start at their offset
+	s := array.NewSlice(a, 10, 20)
+	defer s.Release()
+	got, _, err := FromArray(s)
+	if err != nil || len(got) != 10 || got[1] != ids[11] {
+		t.Fatalf("Expected 10 UUIDs from slice (err=%v), got %v", err, got)
+	}
+
+	b := array.NewInt64Builder(memory.DefaultAllocator)
+	defer b.Release()
+	if _, _, err := FromArray(b.NewArray()); err == nil {
+		t.Fatalf("Expected error reading UUIDs from int64 array")
+	}
+
+}
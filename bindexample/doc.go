@@ -0,0 +1,4 @@
+// Package bindexample shows how to bind UUID path and query parameters with
+// gouuidv6.BindParam in popular web frameworks.  It has no API of its own;
+// see the examples.
+package bindexample
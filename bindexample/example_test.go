@@ -0,0 +1,36 @@
+package bindexample_test
+
+import (
+	"net/http"
+
+	"github.com/bradleypeabody/gouuidv6"
+	"github.com/gin-gonic/gin"
+	"github.com/labstack/echo/v4"
+)
+
+// Binding a path parameter in a gin handler.
+func Example_gin() {
+	r := gin.New()
+	r.GET("/orders/:id", func(c *gin.Context) {
+		id, err := gouuidv6.BindParam(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "id " + err.(*gouuidv6.BindError).Reason})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": id, "created": id.Time()})
+	})
+	_ = r
+}
+
+// Binding a query parameter in an echo handler.
+func Example_echo() {
+	e := echo.New()
+	e.GET("/orders", func(c echo.Context) error {
+		after, err := gouuidv6.BindParam(c.QueryParam("after"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "after "+err.(*gouuidv6.BindError).Reason)
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{"after": after})
+	})
+	_ = e
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// benchmarks run by the bench subcommand, in the order they are reported
+var benchmarks = []struct {
+	name string
+	fn   func(b *testing.B)
+}{
+	{"new", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			gouuidv6.New()
+		}
+	}},
+	{"new-parallel", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				gouuidv6.New()
+			}
+		})
+	}},
+	{"new-b64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			gouuidv6.NewB64()
+		}
+	}},
+	{"string", func(b *testing.B) {
+		u := gouuidv6.New()
+		for i := 0; i < b.N; i++ {
+			_ = u.String()
+		}
+	}},
+	{"parse", func(b *testing.B) {
+		s := gouuidv6.New().String()
+		for i := 0; i < b.N; i++ {
+			gouuidv6.Parse(s)
+		}
+	}},
+	{"parse-b64", func(b *testing.B) {
+		s := gouuidv6.NewB64().String()
+		for i := 0; i < b.N; i++ {
+			gouuidv6.ParseB64(s)
+		}
+	}},
+}
+
+// runBench measures generation and parsing throughput on this machine.
+func runBench(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("bench [-run name]", stderr)
+	only := fs.String("run", "", "only run benchmarks whose name contains this string")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "benchmark\tops/sec\tns/op\tB/op\tallocs/op\t")
+	for _, bm := range benchmarks {
+		if !strings.Contains(bm.name, *only) {
+			continue
+		}
+		fn := bm.fn
+		r := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			fn(b)
+		})
+		opsPerSec := float64(r.N) / r.T.Seconds()
+		fmt.Fprintf(tw, "%s\t%.0f\t%d\t%d\t%d\t\n", bm.name, opsPerSec, r.NsPerOp(), r.AllocedBytesPerOp(), r.AllocsPerOp())
+	}
+	tw.Flush()
+
+	return 0
+}
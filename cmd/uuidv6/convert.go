@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runConvert re-encodes each UUID, or converts it between versions 1 and 6.
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("convert -to b64|hex|v1|v6 [id...]", stderr)
+	to := fs.String("to", "hex", "target: b64, hex, v1 or v6")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	var conv func(u gouuidv6.UUID) (string, error)
+	switch *to {
+	case "hex":
+		conv = func(u gouuidv6.UUID) (string, error) { return u.String(), nil }
+	case "b64":
+		conv = func(u gouuidv6.UUID) (string, error) { return gouuidv6.UUIDB64(u).String(), nil }
+	case "v1":
+		conv = func(u gouuidv6.UUID) (string, error) {
+			if u.Version() == 1 {
+				return u.String(), nil
+			}
+			v1, err := u.ToV1()
+			return v1.String(), err
+		}
+	case "v6":
+		conv = func(u gouuidv6.UUID) (string, error) {
+			if u.Version() == 6 {
+				return u.String(), nil
+			}
+			v6, err := gouuidv6.FromV1(u)
+			return v6.String(), err
+		}
+	default:
+		fmt.Fprintf(stderr, "uuidv6: unknown conversion target %q\n", *to)
+		return 2
+	}
+
+	code := 0
+	err := eachInput(fs.Args(), stdin, func(line int, s string) {
+		u, err := parseID(s)
+		out := ""
+		if err == nil {
+			out, err = conv(u)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: line %d: %q: %v\n", line, s, err)
+			code = 1
+			return
+		}
+		fmt.Fprintln(stdout, out)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	return code
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"convert", "-to", "v6", `f81d4fae-7dec-11d0-a765-00a0c91e6bf6`}, `1d07decf-81d4-6fae-a765-00a0c91e6bf6`},
+		{[]string{"convert", "-to", "v1", `1d07decf-81d4-6fae-a765-00a0c91e6bf6`}, `f81d4fae-7dec-11d0-a765-00a0c91e6bf6`},
+		{[]string{"convert", "-to", "hex", `1d07decf81d46faea76500a0c91e6bf6`}, `1d07decf-81d4-6fae-a765-00a0c91e6bf6`},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(test.args, nil, &stdout, &stderr); code != 0 {
+			t.Fatalf("%v: unexpected exit code %d: %s", test.args, code, stderr.String())
+		}
+		if got := strings.TrimSpace(stdout.String()); got != test.want {
+			t.Errorf("%v: wanted %q, got %q", test.args, test.want, got)
+		}
+	}
+
+	// b64 and back again
+	var stdout, stderr bytes.Buffer
+	run([]string{"convert", "-to", "b64", `1d07decf-81d4-6fae-a765-00a0c91e6bf6`}, nil, &stdout, &stderr)
+	b64 := strings.TrimSpace(stdout.String())
+	stdout.Reset()
+	run([]string{"convert", "-to", "hex"}, strings.NewReader(b64+"\n"), &stdout, &stderr)
+	if got := strings.TrimSpace(stdout.String()); got != `1d07decf-81d4-6fae-a765-00a0c91e6bf6` {
+		t.Errorf("b64 round trip gave %q (via %q)", got, b64)
+	}
+
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runDecode prints the fields of each UUID given on the command line or stdin.
+func runDecode(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("decode [-json] [-raw] [id...]", stderr)
+	jsonOut := fs.Bool("json", false, "write one JSON object per ID instead of plain text")
+	raw := fs.Bool("raw", false, "read 16-byte binary UUIDs from stdin")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *raw && fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "uuidv6: -raw reads from stdin and does not take arguments")
+		return 2
+	}
+
+	first := true
+	enc := json.NewEncoder(stdout)
+	show := func(u gouuidv6.UUID) {
+
+		info := newIDInfo(u)
+		if *jsonOut {
+			enc.Encode(info)
+			return
+		}
+
+		if !first {
+			fmt.Fprintln(stdout)
+		}
+		first = false
+
+		if info.Time == "" {
+			info.Time = "-"
+		}
+
+		node := u.Node()
+		fmt.Fprintf(stdout, "id:       %s\n", info.ID)
+		fmt.Fprintf(stdout, "version:  %d\n", info.Version)
+		fmt.Fprintf(stdout, "variant:  %s\n", info.Variant)
+		fmt.Fprintf(stdout, "time:     %s\n", info.Time)
+		fmt.Fprintf(stdout, "clockseq: %d\n", info.ClockSeq)
+		fmt.Fprintf(stdout, "node:     %s (%v)\n", info.Node, node)
+	}
+
+	code := 0
+	var err error
+	if *raw {
+		err = eachRaw(stdin, func(_ int, u gouuidv6.UUID) { show(u) })
+	} else {
+		err = eachInput(fs.Args(), stdin, func(line int, s string) {
+			u, err := parseID(s)
+			if err != nil {
+				fmt.Fprintf(stderr, "uuidv6: line %d: %q: %v\n", line, s, err)
+				code = 1
+				return
+			}
+			show(u)
+		})
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	return code
+}
+
+var variantNames = map[int]string{
+	0: "NCS",
+	2: "RFC 4122",
+	6: "Microsoft",
+	7: "reserved",
+}
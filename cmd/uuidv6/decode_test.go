@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestDecode(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"decode", `{1e65ced7-cdca-6947-8405-c8bcc8a0b1fd}`}, nil, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"version:  6\n",
+		"variant:  RFC 4122\n",
+		"clockseq: 1029\n",
+		"node:     c8bcc8a0b1fd (c8:bc:c8:a0:b1:fd)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if code := run([]string{"decode", "bogus"}, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1 for bad input, got %d", code)
+	}
+
+}
+
+func TestRaw(t *testing.T) {
+
+	var raw, stdout, stderr bytes.Buffer
+	if code := run([]string{"new", "-raw", "-n", "3"}, nil, &raw, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	if raw.Len() != 48 {
+		t.Fatalf("expected 48 bytes of output, got %d", raw.Len())
+	}
+
+	var u gouuidv6.UUID
+	copy(u[:], raw.Bytes()[32:])
+
+	if code := run([]string{"decode", "-raw", "-json"}, bytes.NewReader(raw.Bytes()), &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[2], u.String()) {
+		t.Fatalf("unexpected decode output:\n%s", stdout.String())
+	}
+
+	if code := run([]string{"decode", "-raw"}, bytes.NewReader(raw.Bytes()[:20]), &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1 for partial input, got %d", code)
+	}
+
+}
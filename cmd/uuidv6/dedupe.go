@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runDedupe prints each distinct input UUID once, in the order first seen.
+// With -sorted the input must already be sorted, and only the previous UUID
+// is remembered so memory use stays constant however large the input.
+func runDedupe(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("dedupe [-sorted] [-report] [id...]", stderr)
+	sorted := fs.Bool("sorted", false, "input is sorted, so only compare each UUID with the one before it")
+	report := fs.Bool("report", false, "write each duplicated UUID and how many times it occurred to stderr")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	code := 0
+
+	var prev gouuidv6.UUID
+	prevCount := 0
+	flushPrev := func() {
+		if *report && prevCount > 1 {
+			fmt.Fprintf(stderr, "%v %d\n", prev, prevCount)
+		}
+	}
+
+	counts := make(map[gouuidv6.UUID]int)
+	var order []gouuidv6.UUID
+
+	err := eachInput(fs.Args(), stdin, func(line int, s string) {
+
+		u, err := parseID(s)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: line %d: %q: %v\n", line, s, err)
+			code = 1
+			return
+		}
+
+		if *sorted {
+			if prevCount > 0 && u == prev {
+				prevCount++
+				return
+			}
+			if prevCount > 0 && bytes.Compare(u[:], prev[:]) < 0 {
+				fmt.Fprintf(stderr, "uuidv6: line %d: %q: input is not sorted\n", line, s)
+				code = 1
+			}
+			flushPrev()
+			prev, prevCount = u, 1
+			fmt.Fprintln(stdout, s)
+			return
+		}
+
+		counts[u]++
+		if counts[u] > 1 {
+			return
+		}
+		if *report {
+			order = append(order, u)
+		}
+		fmt.Fprintln(stdout, s)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	flushPrev()
+	for _, u := range order {
+		if counts[u] > 1 {
+			fmt.Fprintf(stderr, "%v %d\n", u, counts[u])
+		}
+	}
+
+	return code
+}
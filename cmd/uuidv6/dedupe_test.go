@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDedupe(t *testing.T) {
+
+	a := `1e65ced7-cdc6-6e80-8405-c8bcc8a0b1fd`
+	b := `1e65ced7-cdca-6947-8405-c8bcc8a0b1fd`
+	c := `1e65ced7-cdcb-679f-8405-c8bcc8a0b1fd`
+
+	for _, test := range []struct {
+		args []string
+		in   []string
+	}{
+		{[]string{"dedupe", "-report"}, []string{b, a, b, c, a, "{" + b + "}"}},
+		{[]string{"dedupe", "-report", "-sorted"}, []string{a, a, b, b, "{" + b + "}", c}},
+	} {
+
+		var stdout, stderr bytes.Buffer
+		if code := run(test.args, strings.NewReader(strings.Join(test.in, "\n")), &stdout, &stderr); code != 0 {
+			t.Fatalf("%v: unexpected exit code %d: %s", test.args, code, stderr.String())
+		}
+
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("%v: expected 3 distinct IDs, got:\n%s", test.args, stdout.String())
+		}
+
+		if !strings.Contains(stderr.String(), a+" 2\n") || !strings.Contains(stderr.String(), b+" 3\n") {
+			t.Fatalf("%v: unexpected report:\n%s", test.args, stderr.String())
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"dedupe", "-sorted"}, strings.NewReader(b+"\n"+a), &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1 for unsorted input, got %d", code)
+	}
+
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runFilter prints only the UUIDs whose embedded time is at or after -after
+// and before -before.
+func runFilter(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("filter [-after time] [-before time] [id...]", stderr)
+	after := fs.String("after", "", "keep IDs created at or after this time (RFC3339 or YYYY-MM-DD)")
+	before := fs.String("before", "", "keep IDs created before this time (RFC3339 or YYYY-MM-DD)")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	var lower, upper []byte
+	if *after != "" {
+		t, err := parseTime(*after)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: invalid -after: %v\n", err)
+			return 2
+		}
+		first := gouuidv6.FirstForTime(t)
+		lower = first[:]
+	}
+	if *before != "" {
+		t, err := parseTime(*before)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: invalid -before: %v\n", err)
+			return 2
+		}
+		first := gouuidv6.FirstForTime(t)
+		upper = first[:]
+	}
+
+	code := 0
+	err := eachInput(fs.Args(), stdin, func(line int, s string) {
+
+		u, err := parseID(s)
+		if err == nil && !u.IsValid() {
+			err = fmt.Errorf("not a version 6 UUID")
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: line %d: %q: %v\n", line, s, err)
+			code = 1
+			return
+		}
+
+		if lower != nil && bytes.Compare(u[:], lower) < 0 {
+			return
+		}
+		if upper != nil && bytes.Compare(u[:], upper) >= 0 {
+			return
+		}
+		fmt.Fprintln(stdout, s)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	return code
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestFilter(t *testing.T) {
+
+	jan := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	in := strings.Join([]string{
+		gouuidv6.NewFromTime(jan.AddDate(0, -1, 0)).String(),
+		gouuidv6.NewFromTime(jan).String(),
+		gouuidv6.NewFromTime(feb.Add(-time.Microsecond)).String(),
+		gouuidv6.NewFromTime(feb).String(),
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"filter", "-after", "2024-01-01T00:00:00Z", "-before", "2024-02-01"}, strings.NewReader(in), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	lines := strings.Split(in, "\n")
+	if want := lines[1] + "\n" + lines[2] + "\n"; stdout.String() != want {
+		t.Fatalf("wanted:\n%s\ngot:\n%s", want, stdout.String())
+	}
+
+}
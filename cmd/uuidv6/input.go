@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// eachInput calls fn with each ID given in args, or if there are none, with
+// each non-blank line read from stdin.  The line number passed to fn is the
+// 1-based position of the ID in whichever input was used.
+func eachInput(args []string, stdin io.Reader, fn func(line int, s string)) error {
+
+	if len(args) > 0 {
+		for i, s := range args {
+			fn(i+1, s)
+		}
+		return nil
+	}
+
+	sc := bufio.NewScanner(stdin)
+	line := 0
+	for sc.Scan() {
+		line++
+		s := strings.TrimSpace(sc.Text())
+		if s == "" {
+			continue
+		}
+		fn(line, s)
+	}
+	return sc.Err()
+}
+
+// eachRaw calls fn with each 16-byte binary UUID read from r, numbering
+// them from 1.  It is an error for the input to end part way through a UUID.
+func eachRaw(r io.Reader, fn func(rec int, u gouuidv6.UUID)) error {
+	r = bufio.NewReader(r)
+	for rec := 1; ; rec++ {
+		var u gouuidv6.UUID
+		n, err := io.ReadFull(r, u[:])
+		if err == io.EOF {
+			return nil
+		}
+		if err == io.ErrUnexpectedEOF {
+			return fmt.Errorf("input ends with a partial UUID of %d bytes", n)
+		}
+		if err != nil {
+			return err
+		}
+		fn(rec, u)
+	}
+}
+
+// parseID accepts any of the representations produced by -format except
+// b32hex, which cannot be told apart from b32
+func parseID(s string) (gouuidv6.UUID, error) {
+
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "urn:uuid:"), "URN:UUID:")
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+
+	switch len(s) {
+	case 22:
+		u, err := gouuidv6.ParseB64(s)
+		return gouuidv6.UUID(u), err
+	case 26:
+		u, err := gouuidv6.ParseB32(s)
+		return gouuidv6.UUID(u), err
+	case 32:
+		s = s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+	case 36:
+	default:
+		return gouuidv6.UUID{}, fmt.Errorf("unrecognized UUID length %d", len(s))
+	}
+
+	// check the layout first, to say where it is wrong
+	for i, c := range s {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if c != '-' {
+				return gouuidv6.UUID{}, fmt.Errorf("expected '-' at position %d", i+1)
+			}
+		} else if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return gouuidv6.UUID{}, fmt.Errorf("invalid character %q at position %d", c, i+1)
+		}
+	}
+
+	return gouuidv6.Parse(s)
+}
+
+// parseTime accepts an RFC3339 timestamp or a plain YYYY-MM-DD date (UTC)
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStdin(t *testing.T) {
+
+	in := strings.NewReader("1e65ced7-cdca-6947-8405-c8bcc8a0b1fd\n\n  1e65ced7-cdca-694f-8405-c8bcc8a0b1fd  \nbogus\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"decode"}, in, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1 for bad line, got %d", code)
+	}
+
+	if n := strings.Count(stdout.String(), "id:"); n != 2 {
+		t.Fatalf("expected 2 decoded IDs, got %d:\n%s", n, stdout.String())
+	}
+
+	if !strings.Contains(stderr.String(), "line 4:") {
+		t.Fatalf("expected error for line 4, got: %s", stderr.String())
+	}
+
+}
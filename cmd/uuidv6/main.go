@@ -0,0 +1,117 @@
+// Command uuidv6 generates, inspects and converts "Version 6" UUIDs.
+//
+// Usage:
+//
+//	uuidv6 <command> [flags] [id...]
+//
+// Run "uuidv6 help" for the list of commands, and "uuidv6 <command> -h" for
+// the flags each one takes.  Running uuidv6 with no command (or with only
+// flags) is the same as "uuidv6 new".
+//
+// Commands that take IDs read them one per line from standard input when
+// none are given as arguments.  IDs may be in any of the hex forms (plain,
+// compact, braced or urn) or the base64 form.
+//
+// The exit status is 0 on success, 1 if any input was invalid or an
+// operation failed, and 2 for incorrect usage.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// command is one uuidv6 subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// commands in the order they are listed by "uuidv6 help"; initialized in
+// init since usage refers back to it
+var commands []command
+
+func init() {
+	commands = []command{
+		{"new", "generate new UUIDs", runNew},
+		{"decode", "print the fields of UUIDs", runDecode},
+		{"convert", "re-encode UUIDs or convert between versions 1 and 6", runConvert},
+		{"upgrade-v1", "convert version 1 UUIDs to version 6, optionally as old,new CSV", runUpgradeV1},
+		{"validate", "check UUIDs are well formed version 6 with plausible times", runValidate},
+		{"sort", "sort UUIDs (and so by time)", runSort},
+		{"dedupe", "remove duplicate UUIDs", runDedupe},
+		{"range", "print the lowest and highest UUIDs and the time between them", runRange},
+		{"filter", "keep UUIDs created within a time range", runFilter},
+		{"stats", "summarize a set of UUIDs", runStats},
+		{"stream", "write new UUIDs continuously at a fixed rate", runStream},
+		{"serve", "serve new UUIDs over HTTP", runServe},
+		{"bench", "measure generation and parsing performance", runBench},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	// no command means "new"
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "-help" && args[0] != "--help") {
+		return runNew(args, stdin, stdout, stderr)
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return 0
+	}
+
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], stdin, stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "uuidv6: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+// usage writes the list of commands
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: uuidv6 <command> [flags] [id...]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(w, "\nRun \"uuidv6 <command> -h\" for the flags of each command.\n")
+}
+
+// newFlagSet returns a flag set for a command, where synopsis is the command
+// name followed by a summary of its arguments (printed for -h or bad flags)
+func newFlagSet(synopsis string, stderr io.Writer) *flag.FlagSet {
+	name := strings.Fields(synopsis)[0]
+	fs := flag.NewFlagSet("uuidv6 "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: uuidv6 %s\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args into fs, returning false and the exit code to use
+// if the command should not go on to run
+func parseFlags(fs *flag.FlagSet, args []string) (bool, int) {
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return false, 0
+	}
+	if err != nil {
+		return false, 2
+	}
+	return true, 0
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCommands(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"help"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d for help", code)
+	}
+	for _, c := range commands {
+		if !strings.Contains(stdout.String(), "  "+c.name+" ") {
+			t.Errorf("usage does not list %q:\n%s", c.name, stdout.String())
+		}
+	}
+
+	if code := run([]string{"frobnicate"}, nil, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit code 2 for unknown command, got %d", code)
+	}
+
+	if code := run([]string{"decode", "-bogus"}, nil, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit code 2 for unknown flag, got %d", code)
+	}
+
+	stderr.Reset()
+	if code := run([]string{"convert", "-h"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0 for -h, got %d", code)
+	}
+	if !strings.HasPrefix(stderr.String(), "usage: uuidv6 convert") {
+		t.Fatalf("unexpected -h output: %s", stderr.String())
+	}
+
+	// bare flags and "new" are the same thing
+	stdout.Reset()
+	run([]string{"-n", "2"}, nil, &stdout, &stderr)
+	run([]string{"new", "-n", "2"}, nil, &stdout, &stderr)
+	if n := strings.Count(stdout.String(), "\n"); n != 4 {
+		t.Fatalf("expected 4 IDs, got %d", n)
+	}
+
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base32"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// base32 using the "extended hex" alphabet, which (like Base64UUIDAlphabet)
+// keeps the encoded form sorting the same as the raw bytes, for -format b32hex
+var b32HexEncoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// formatters maps each -format name to a function producing that representation
+var formatters = map[string]func(u gouuidv6.UUID) string{
+	"hex":     func(u gouuidv6.UUID) string { return u.String() },
+	"b64":     func(u gouuidv6.UUID) string { return gouuidv6.UUIDB64(u).String() },
+	"b32":     func(u gouuidv6.UUID) string { return gouuidv6.UUIDB32(u).String() },
+	"b32hex":  func(u gouuidv6.UUID) string { return strings.ToLower(b32HexEncoding.EncodeToString(u[:])) },
+	"urn":     func(u gouuidv6.UUID) string { return "urn:uuid:" + u.String() },
+	"braced":  func(u gouuidv6.UUID) string { return "{" + u.String() + "}" },
+	"compact": func(u gouuidv6.UUID) string { return strings.Replace(u.String(), "-", "", -1) },
+}
+
+// runNew generates new UUIDs.
+func runNew(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("new [-n count] [-format name] [-node hex | -node-from-iface name] [-time time | -start time [-seed n]] [-clockseq n] [-json | -raw]", stderr)
+	n := fs.Int("n", 1, "number of UUIDs to generate")
+	format := fs.String("format", "hex", "output encoding: hex, b64, b32, b32hex, urn, braced or compact")
+	nodeStr := fs.String("node", "", "fixed 48-bit node, e.g. 0xdeadbeef (default is the package default node)")
+	iface := fs.String("node-from-iface", "", "use the MAC address of this network interface as the node")
+	timeStr := fs.String("time", "", "fixed timestamp (RFC3339 or YYYY-MM-DD) instead of the current time")
+	startStr := fs.String("start", "", "generate a reproducible sequence starting at this time, advancing 100ns per UUID")
+	seed := fs.Int64("seed", 0, "with -start, seed for the node and clock sequence of the sequence")
+	clockseq := fs.Int("clockseq", -1, "starting clock sequence, 0-16383 (default random)")
+	jsonOut := fs.Bool("json", false, "write one JSON object per ID instead of plain text")
+	raw := fs.Bool("raw", false, "write each UUID as 16 raw bytes instead of text")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	f, ok := formatters[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "uuidv6: unknown format %q\n", *format)
+		return 2
+	}
+
+	if *raw && *jsonOut {
+		fmt.Fprintln(stderr, "uuidv6: -raw and -json are mutually exclusive")
+		return 2
+	}
+
+	newFn := gouuidv6.New
+	var opts []gouuidv6.GeneratorOption
+
+	switch {
+	case *nodeStr != "" && *iface != "":
+		fmt.Fprintln(stderr, "uuidv6: -node and -node-from-iface are mutually exclusive")
+		return 2
+	case *nodeStr != "":
+		node, err := strconv.ParseUint(*nodeStr, 0, 48)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: invalid -node: %v\n", err)
+			return 2
+		}
+		opts = append(opts, gouuidv6.WithNode(node))
+	case *iface != "":
+		node, err := gouuidv6.NodeFromInterface(*iface)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+			return 1
+		}
+		opts = append(opts, gouuidv6.WithNode(node))
+	}
+
+	if *clockseq >= 0 {
+		if *clockseq > 0x3fff {
+			fmt.Fprintln(stderr, "uuidv6: -clockseq must be between 0 and 16383")
+			return 2
+		}
+		opts = append(opts, gouuidv6.WithClockSeq(uint16(*clockseq)))
+	}
+
+	if *timeStr != "" {
+		t, err := parseTime(*timeStr)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: invalid -time: %v\n", err)
+			return 2
+		}
+		opts = append(opts, gouuidv6.WithTimeFunc(func() time.Time { return t }))
+	}
+
+	seedSet := false
+	fs.Visit(func(f *flag.Flag) { seedSet = seedSet || f.Name == "seed" })
+
+	switch {
+	case *startStr != "" && *timeStr != "":
+		fmt.Fprintln(stderr, "uuidv6: -start and -time are mutually exclusive")
+		return 2
+	case *startStr != "":
+		start, err := parseTime(*startStr)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: invalid -start: %v\n", err)
+			return 2
+		}
+		newFn = gouuidv6.NewDeterministicGenerator(*seed, start, opts...).New
+	case seedSet:
+		fmt.Fprintln(stderr, "uuidv6: -seed requires -start")
+		return 2
+	case len(opts) > 0:
+		newFn = gouuidv6.NewGenerator(opts...).New
+	}
+
+	if *raw {
+		w := bufio.NewWriter(stdout)
+		for i := 0; i < *n; i++ {
+			u := newFn()
+			w.Write(u[:])
+		}
+		if err := w.Flush(); err != nil {
+			fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	enc := json.NewEncoder(stdout)
+	for i := 0; i < *n; i++ {
+		u := newFn()
+		if *jsonOut {
+			info := newIDInfo(u)
+			info.ID = f(u)
+			enc.Encode(info)
+			continue
+		}
+		fmt.Fprintln(stdout, f(u))
+	}
+
+	return 0
+}
+
+// idInfo holds the decoded fields of a UUID, for -json output
+type idInfo struct {
+	ID       string `json:"id"`
+	Version  int    `json:"version"`
+	Variant  string `json:"variant"`
+	Time     string `json:"time,omitempty"`
+	ClockSeq uint16 `json:"clockseq"`
+	Node     string `json:"node"`
+}
+
+func newIDInfo(u gouuidv6.UUID) idInfo {
+	info := idInfo{
+		ID:       u.String(),
+		Version:  u.Version(),
+		Variant:  variantNames[u.Variant()],
+		ClockSeq: u.ClockSeq(),
+		Node:     fmt.Sprintf("%x", []byte(u.Node())),
+	}
+	if t := u.Time(); !t.IsZero() {
+		info.Time = t.UTC().Format(time.RFC3339Nano)
+	}
+	return info
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestFormat(t *testing.T) {
+
+	u, _ := gouuidv6.Parse(`1e65ced7-cdca-6947-8405-c8bcc8a0b1fd`)
+
+	expected := map[string]string{
+		"hex":     `1e65ced7-cdca-6947-8405-c8bcc8a0b1fd`,
+		"urn":     `urn:uuid:1e65ced7-cdca-6947-8405-c8bcc8a0b1fd`,
+		"braced":  `{1e65ced7-cdca-6947-8405-c8bcc8a0b1fd}`,
+		"compact": `1e65ced7cdca69478405c8bcc8a0b1fd`,
+		"b64":     gouuidv6.UUIDB64(u).String(),
+		"b32":     gouuidv6.UUIDB32(u).String(),
+	}
+
+	for name, want := range expected {
+		if got := formatters[name](u); got != want {
+			t.Errorf("format %q: wanted %q, got %q", name, want, got)
+		}
+	}
+
+	if len(formatters["b32hex"](u)) != 26 {
+		t.Errorf("b32hex value has unexpected length: %q", formatters["b32hex"](u))
+	}
+
+	for name, f := range formatters {
+		if name == "b32hex" {
+			continue
+		}
+		if got, err := parseID(f(u)); err != nil || got != u {
+			t.Errorf("format %q: parsed %q as %v (err=%v)", name, f(u), got, err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-n", "3", "-format", "braced"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "{") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+
+	if code := run([]string{"-format", "nope"}, nil, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit code 2 for unknown format, got %d", code)
+	}
+
+}
+
+func TestControlledGeneration(t *testing.T) {
+
+	args := []string{"-n", "2", "-node", "0xdeadbeef", "-time", "2024-06-01T12:00:00Z", "-clockseq", "0"}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(args, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	want := "1ef200e7-8cb6-6000-8000-0000deadbeef\n1ef200e7-8cb6-6000-8001-0000deadbeef\n"
+	if stdout.String() != want {
+		t.Fatalf("wanted:\n%s\ngot:\n%s", want, stdout.String())
+	}
+
+	if code := run([]string{"-node", "0x1000000000000"}, nil, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit code 2 for oversized node, got %d", code)
+	}
+
+}
+
+func TestJSON(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-json", "-format", "b64", "-node", "0xdeadbeef", "-time", "2024-06-01T12:00:00Z", "-clockseq", "7"}
+	if code := run(args, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	var info idInfo
+	if err := json.Unmarshal(stdout.Bytes(), &info); err != nil {
+		t.Fatalf("output is not JSON: %v: %s", err, stdout.String())
+	}
+
+	u, _ := gouuidv6.Parse(`1ef200e7-8cb6-6000-8007-0000deadbeef`)
+	want := idInfo{
+		ID:       gouuidv6.UUIDB64(u).String(),
+		Version:  6,
+		Variant:  "RFC 4122",
+		Time:     "2024-06-01T12:00:00Z",
+		ClockSeq: 7,
+		Node:     "0000deadbeef",
+	}
+	if info != want {
+		t.Fatalf("wanted %+v, got %+v", want, info)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"decode", "-json", u.String(), u.String()}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got: %s", stdout.String())
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &info); err != nil || info.ID != u.String() {
+		t.Fatalf("unexpected decode output (err=%v): %s", err, lines[1])
+	}
+
+}
+
+func TestSeeded(t *testing.T) {
+
+	args := []string{"new", "-seed", "42", "-start", "2024-01-01T00:00:00Z", "-n", "100"}
+
+	var out1, out2, stderr bytes.Buffer
+	if code := run(args, nil, &out1, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	run(args, nil, &out2, &stderr)
+
+	if out1.String() != out2.String() {
+		t.Fatalf("seeded output differed between runs")
+	}
+	if n := strings.Count(out1.String(), "\n"); n != 100 {
+		t.Fatalf("expected 100 IDs, got %d", n)
+	}
+
+	if code := run([]string{"new", "-seed", "42"}, nil, &out1, &stderr); code != 2 {
+		t.Fatalf("expected exit code 2 for -seed without -start, got %d", code)
+	}
+
+}
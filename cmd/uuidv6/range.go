@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runRange prints the lowest and highest input UUIDs, their times and the
+// duration between them.
+func runRange(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("range [id...]", stderr)
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	code := 0
+	count := 0
+	var min, max gouuidv6.UUID
+	err := eachInput(fs.Args(), stdin, func(line int, s string) {
+
+		u, err := parseID(s)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: line %d: %q: %v\n", line, s, err)
+			code = 1
+			return
+		}
+
+		if count == 0 || bytes.Compare(u[:], min[:]) < 0 {
+			min = u
+		}
+		if count == 0 || bytes.Compare(u[:], max[:]) > 0 {
+			max = u
+		}
+		count++
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	if count == 0 {
+		fmt.Fprintln(stderr, "uuidv6: no input")
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "count: %d\n", count)
+	fmt.Fprintf(stdout, "min:   %v %s\n", min, formatTime(min.Time()))
+	fmt.Fprintf(stdout, "max:   %v %s\n", max, formatTime(max.Time()))
+	if !min.Time().IsZero() && !max.Time().IsZero() {
+		fmt.Fprintf(stdout, "span:  %v\n", max.Time().Sub(min.Time()))
+	}
+
+	return code
+}
+
+// formatTime formats t for output, or "-" for the zero time returned for
+// UUIDs that are not version 6
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
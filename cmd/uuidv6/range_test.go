@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestRange(t *testing.T) {
+
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := gouuidv6.NewGenerator()
+	a := g.NewFromTime(start.Add(time.Hour))
+	b := g.NewFromTime(start)
+	c := g.NewFromTime(start.Add(90 * time.Minute))
+	d := g.NewFromTime(start.Add(time.Minute))
+
+	in := strings.Join([]string{a.String(), b.String(), c.String(), d.String()}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"range"}, strings.NewReader(in), &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	want := "count: 4\n" +
+		"min:   " + b.String() + " 2024-06-01T12:00:00Z\n" +
+		"max:   " + c.String() + " 2024-06-01T13:30:00Z\n" +
+		"span:  1h30m0s\n"
+	if stdout.String() != want {
+		t.Fatalf("wanted:\n%s\ngot:\n%s", want, stdout.String())
+	}
+
+}
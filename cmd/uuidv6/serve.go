@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runServe serves new UUIDs over HTTP using gouuidv6.IDHandler.
+func runServe(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("serve [-addr host:port] [-max-count n]", stderr)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	maxCount := fs.Int("max-count", 1000, "most UUIDs returned for one request")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	fmt.Fprintf(stderr, "uuidv6: serving on %s\n", *addr)
+	err := http.ListenAndServe(*addr, &gouuidv6.IDHandler{MaxCount: *maxCount})
+	fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+	return 1
+}
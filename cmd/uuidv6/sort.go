@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runSort prints the input UUIDs sorted by their binary value, which for
+// version 6 UUIDs is the order they were created in.  With -external or -raw
+// it uses gouuidv6.ExternalSorter, for input larger than memory.
+func runSort(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("sort [-r] [-external] [-raw] [-chunk n] [id...]", stderr)
+	reverse := fs.Bool("r", false, "sort in descending order")
+	external := fs.Bool("external", false, "sort on disk, for input larger than memory; accepts only the hex and base64 forms and writes hex")
+	raw := fs.Bool("raw", false, "read and write 16-byte binary UUIDs from stdin, sorting on disk")
+	chunk := fs.Int("chunk", 1<<20, "with -external or -raw, the most UUIDs to hold in memory")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *raw && fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "uuidv6: -raw reads from stdin and does not take arguments")
+		return 2
+	}
+	if *external || *raw {
+		in := stdin
+		if fs.NArg() > 0 {
+			in = strings.NewReader(strings.Join(fs.Args(), "\n"))
+		}
+		s := &gouuidv6.ExternalSorter{Binary: *raw, Reverse: *reverse, ChunkSize: *chunk}
+		if err := s.Sort(stdout, in); err != nil {
+			fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	type entry struct {
+		u gouuidv6.UUID
+		s string
+	}
+	var entries []entry
+
+	code := 0
+	err := eachInput(fs.Args(), stdin, func(line int, s string) {
+		u, err := parseID(s)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: line %d: %q: %v\n", line, s, err)
+			code = 1
+			return
+		}
+		entries = append(entries, entry{u, s})
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		c := bytes.Compare(entries[i].u[:], entries[j].u[:])
+		if *reverse {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	for _, e := range entries {
+		fmt.Fprintln(stdout, e.s)
+	}
+
+	return code
+}
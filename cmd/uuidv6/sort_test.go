@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+
+	in := strings.Join([]string{
+		`1e65ced7-cdca-6947-8405-c8bcc8a0b1fd`,
+		`1e65ced7-cdcb-679f-8405-c8bcc8a0b1fd`, // last
+		`1e65ced7-cdc6-6e80-8405-c8bcc8a0b1fd`, // first
+		`{1e65ced7-cdca-694f-8405-c8bcc8a0b1fd}`,
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"sort"}, strings.NewReader(in), &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	want := strings.Join([]string{
+		`1e65ced7-cdc6-6e80-8405-c8bcc8a0b1fd`,
+		`1e65ced7-cdca-6947-8405-c8bcc8a0b1fd`,
+		`{1e65ced7-cdca-694f-8405-c8bcc8a0b1fd}`,
+		`1e65ced7-cdcb-679f-8405-c8bcc8a0b1fd`,
+	}, "\n") + "\n"
+	if stdout.String() != want {
+		t.Fatalf("wanted:\n%s\ngot:\n%s", want, stdout.String())
+	}
+
+	stdout.Reset()
+	run([]string{"sort", "-r"}, strings.NewReader(in), &stdout, &stderr)
+	if !strings.HasPrefix(stdout.String(), `1e65ced7-cdcb-679f`) {
+		t.Fatalf("expected reverse sort, got:\n%s", stdout.String())
+	}
+
+	// -external takes and writes only the plain hex form
+	unbrace := strings.NewReplacer("{", "", "}", "")
+	stdout.Reset()
+	if code := run([]string{"sort", "-external", "-chunk", "2"}, strings.NewReader(unbrace.Replace(in)), &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	if want := unbrace.Replace(want); stdout.String() != want {
+		t.Fatalf("wanted:\n%s\ngot:\n%s", want, stdout.String())
+	}
+
+}
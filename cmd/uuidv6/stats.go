@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runStats summarizes a set of UUIDs with gouuidv6.Analyze: how many, from how
+// many nodes, over what time span and at what rate, and whether any are
+// duplicated or out of order.
+func runStats(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("stats [-histogram second|minute] [id...]", stderr)
+	histogram := fs.String("histogram", "", "also print the count of IDs per second or minute")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	var histDur time.Duration
+	switch *histogram {
+	case "":
+	case "second":
+		histDur = time.Second
+	case "minute":
+		histDur = time.Minute
+	default:
+		fmt.Fprintf(stderr, "uuidv6: unknown histogram interval %q\n", *histogram)
+		return 2
+	}
+
+	var ids []gouuidv6.UUID
+	unparsed := 0
+	err := eachInput(fs.Args(), stdin, func(line int, s string) {
+		u, err := parseID(s)
+		if err != nil {
+			unparsed++
+			return
+		}
+		ids = append(ids, u)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	perSecond := gouuidv6.Analyze(ids, time.Second)
+	perMinute := gouuidv6.Analyze(ids, time.Minute)
+	a := perSecond
+
+	fmt.Fprintf(stdout, "count:       %d\n", a.Count)
+	fmt.Fprintf(stdout, "invalid:     %d\n", unparsed+a.Invalid)
+	fmt.Fprintf(stdout, "duplicates:  %d (of %d distinct values)\n", a.Duplicates, a.DuplicateValues)
+	fmt.Fprintf(stdout, "nodes:       %d\n", a.Nodes)
+	if a.Count == 0 {
+		return 0
+	}
+
+	fmt.Fprintf(stdout, "first:       %s\n", a.First.UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(stdout, "last:        %s\n", a.Last.UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(stdout, "span:        %v\n", a.Span)
+	fmt.Fprintf(stdout, "per second:  peak %d, mean %.2f\n", peak(perSecond.Buckets), float64(a.Count)/float64(len(perSecond.Buckets)))
+	fmt.Fprintf(stdout, "per minute:  peak %d, mean %.2f\n", peak(perMinute.Buckets), float64(a.Count)/float64(len(perMinute.Buckets)))
+	fmt.Fprintf(stdout, "unordered:   %.2f%%\n", a.OutOfOrder*100)
+	fmt.Fprintf(stdout, "same tick:   %d (%.2g collisions expected if clock sequences were random)\n", a.SharedTicks, a.CollisionEstimate)
+
+	if histDur > 0 {
+		buckets := perSecond.Buckets
+		if histDur == time.Minute {
+			buckets = perMinute.Buckets
+		}
+		fmt.Fprintln(stdout)
+		for _, b := range buckets {
+			fmt.Fprintf(stdout, "%s %d\n", b.Start.Format(time.RFC3339), b.Count)
+		}
+	}
+
+	return 0
+}
+
+// peak returns the highest count in a set of time buckets
+func peak(buckets []gouuidv6.Bucket) int {
+	max := 0
+	for _, b := range buckets {
+		if b.Count > max {
+			max = b.Count
+		}
+	}
+	return max
+}
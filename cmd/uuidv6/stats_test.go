@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestStats(t *testing.T) {
+
+	g := gouuidv6.NewGenerator(gouuidv6.WithNode(1))
+	g2 := gouuidv6.NewGenerator(gouuidv6.WithNode(2))
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, g.NewFromTime(start.Add(time.Duration(i)*time.Second)).String())
+	}
+	ids = append(ids, g2.NewFromTime(start.Add(90*time.Second)).String())
+	ids = append(ids, ids[0], "bogus")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"stats", "-histogram", "minute"}, strings.NewReader(strings.Join(ids, "\n")), &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"count:       7\n",
+		"invalid:     1\n",
+		"duplicates:  1 (of 1 distinct values)\n",
+		"nodes:       2\n",
+		"span:        1m30s\n",
+		"per second:  peak 2, mean 1.17\n",
+		"per minute:  peak 6, mean 3.50\n",
+		"2024-06-01T12:00:00Z 6\n2024-06-01T12:01:00Z 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+}
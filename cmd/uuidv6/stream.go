@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runStream writes IDs at a steady rate until interrupted or (if -n is set)
+// that many have been written.
+func runStream(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("stream [-rate n/unit] [-n count] [-format name]", stderr)
+	rateStr := fs.String("rate", "1/s", "IDs per unit of time, e.g. 1000/s, 50/ms or 600/m")
+	n := fs.Int("n", 0, "stop after this many IDs (default unlimited)")
+	format := fs.String("format", "hex", "output encoding: hex, b64, b32, b32hex, urn, braced or compact")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	f, ok := formatters[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "uuidv6: unknown format %q\n", *format)
+		return 2
+	}
+
+	rate, err := parseRate(*rateStr)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: invalid -rate: %v\n", err)
+		return 2
+	}
+
+	// tick at the rate wanted, but no more often than every millisecond;
+	// at higher rates each tick writes however many IDs are due by then
+	interval := time.Duration(float64(time.Second) / rate)
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+
+	w := bufio.NewWriter(stdout)
+	start := time.Now()
+	written := 0
+	for {
+
+		due := int(rate*time.Since(start).Seconds()) + 1
+		if *n > 0 && due > *n {
+			due = *n
+		}
+		for ; written < due; written++ {
+			w.WriteString(f(gouuidv6.New()))
+			w.WriteByte('\n')
+		}
+		if err := w.Flush(); err != nil {
+			fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+			return 1
+		}
+
+		if *n > 0 && written >= *n {
+			return 0
+		}
+
+		select {
+		case <-stop:
+			return 0
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseRate parses "N/unit" (unit is ns, us, ms, s, m or h) or a plain N per
+// second, returning the number of events per second
+func parseRate(s string) (float64, error) {
+
+	num, unit := s, "s"
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		num, unit = s[:i], s[i+1:]
+	}
+
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("rate must be positive")
+	}
+
+	d, err := time.ParseDuration("1" + unit)
+	if err != nil {
+		return 0, fmt.Errorf("unknown unit %q", unit)
+	}
+
+	return n / d.Seconds(), nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+
+	for s, want := range map[string]float64{"1000/s": 1000, "5/ms": 5000, "600/m": 10, "20": 20} {
+		if got, err := parseRate(s); err != nil || got != want {
+			t.Errorf("parseRate(%q): wanted %v, got %v (err=%v)", s, want, got, err)
+		}
+	}
+	if _, err := parseRate("10/fortnight"); err == nil {
+		t.Errorf("parseRate should fail for an unknown unit")
+	}
+
+	var stdout, stderr bytes.Buffer
+	start := time.Now()
+	if code := runStream([]string{"-rate", "1000/s", "-n", "100"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	if d := time.Since(start); d < 90*time.Millisecond {
+		t.Fatalf("100 IDs at 1000/s took only %v", d)
+	}
+	if n := strings.Count(stdout.String(), "\n"); n != 100 {
+		t.Fatalf("expected 100 IDs, got %d", n)
+	}
+
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// runUpgradeV1 converts version 1 UUIDs to the equivalent version 6 ones with
+// gouuidv6.FromV1, optionally as "old,new" CSV rows for re-keying.
+func runUpgradeV1(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("upgrade-v1 [-csv] [-header] [id...]", stderr)
+	csv := fs.Bool("csv", false, "write old,new pairs as CSV instead of just the new UUIDs")
+	header := fs.Bool("header", false, "with -csv, start with a v1,v6 header row")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	w := bufio.NewWriter(stdout)
+	if *csv && *header {
+		w.WriteString("v1,v6\n")
+	}
+
+	code := 0
+	err := eachInput(fs.Args(), stdin, func(line int, s string) {
+
+		u, err := parseID(s)
+		var v6 gouuidv6.UUID
+		if err == nil {
+			v6, err = gouuidv6.FromV1(u)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidv6: line %d: %q: %v\n", line, s, err)
+			code = 1
+			return
+		}
+
+		if *csv {
+			w.WriteString(u.String())
+			w.WriteByte(',')
+		}
+		w.WriteString(v6.String())
+		w.WriteByte('\n')
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	return code
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestUpgradeV1(t *testing.T) {
+
+	in := "F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6\n1d07decf-81d4-6fae-a765-00a0c91e6bf6\n"
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"upgrade-v1", "-csv", "-header"}, strings.NewReader(in), &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1 for the version 6 input, got %d", code)
+	}
+
+	want := "v1,v6\nf81d4fae-7dec-11d0-a765-00a0c91e6bf6,1d07decf-81d4-6fae-a765-00a0c91e6bf6\n"
+	if stdout.String() != want {
+		t.Fatalf("wanted:\n%s\ngot:\n%s", want, stdout.String())
+	}
+
+	if !strings.Contains(stderr.String(), "line 2:") {
+		t.Fatalf("expected an error for line 2, got: %s", stderr.String())
+	}
+
+}
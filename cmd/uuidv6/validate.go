@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// runValidate reports each input line that is not a well formed "Version 6"
+// UUID with a plausible timestamp, exiting with status 1 if there are any.
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := newFlagSet("validate [-min time] [-max-skew duration] [id...]", stderr)
+	min := fs.String("min", "1970-01-01T00:00:00Z", "earliest plausible timestamp (RFC3339)")
+	maxSkew := fs.Duration("max-skew", 24*time.Hour, "how far into the future a timestamp may be")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	mint, err := time.Parse(time.RFC3339, *min)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: invalid -min: %v\n", err)
+		return 2
+	}
+	maxt := time.Now().Add(*maxSkew)
+
+	code := 0
+	err = eachInput(fs.Args(), stdin, func(line int, s string) {
+
+		u, err := parseID(s)
+		switch {
+		case err != nil:
+		case u.Version() != 6:
+			err = fmt.Errorf("version is %d, not 6", u.Version())
+		case u.Variant() != 2:
+			err = fmt.Errorf("variant is %s, not RFC 4122", variantNames[u.Variant()])
+		case u.Time().Before(mint):
+			err = fmt.Errorf("timestamp %v is before %v", u.Time().UTC().Format(time.RFC3339), *min)
+		case u.Time().After(maxt):
+			err = fmt.Errorf("timestamp %v is in the future", u.Time().UTC().Format(time.RFC3339))
+		}
+
+		if err != nil {
+			fmt.Fprintf(stdout, "line %d: %q: %v\n", line, s, err)
+			code = 1
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidv6: %v\n", err)
+		return 1
+	}
+
+	return code
+}
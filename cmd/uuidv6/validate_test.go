@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestValidate(t *testing.T) {
+
+	in := strings.Join([]string{
+		gouuidv6.New().String(),
+		`f81d4fae-7dec-11d0-a765-00a0c91e6bf6`, // version 1
+		`1e65ced7-cdca-6947-c405-c8bcc8a0b1fd`, // wrong variant
+		`1e65ced7-cdca-6947-8405-c8bcc8a0b1f`,  // short
+		`1e65ced7+cdca-6947-8405-c8bcc8a0b1fd`, // bad separator
+		`1b21dd21-3813-6000-8000-000000000000`, // just before 1970
+		gouuidv6.NewFromTime(time.Now().Add(48 * time.Hour)).String(),
+		gouuidv6.New().String(),
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate"}, strings.NewReader(in), &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 failures, got:\n%s", stdout.String())
+	}
+	for i, line := range lines {
+		if want := fmt.Sprintf("line %d:", i+2); !strings.HasPrefix(line, want) {
+			t.Errorf("expected failure to start with %q, got %q", want, line)
+		}
+	}
+
+	stdout.Reset()
+	if code := run([]string{"validate", gouuidv6.New().String()}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stdout.String())
+	}
+
+}
@@ -0,0 +1,3 @@
+module github.com/bradleypeabody/gouuidv6
+
+go 1.20
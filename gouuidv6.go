@@ -6,13 +6,18 @@
 package gouuidv6
 
 import (
-	"crypto/rand"
+	"crypto/hmac"
+	"crypto/sha256"
 	"database/sql/driver"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
-	"sync"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -33,32 +38,134 @@ func (s UUIDSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 // Textual representation per RFC 4122, e.g. "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
 func (u UUID) String() string {
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", u[:4], u[4:6], u[6:8], u[8:10], u[10:])
+	var b [36]byte
+	encodeHex(b[:], u)
+	return string(b[:])
 }
 
-// Parse text representation
-func Parse(us string) (UUID, error) {
-	var ret UUID
-	var v1, v2, v3, v4, v5 uint64
-	_, err := fmt.Sscanf(us, "%08x-%04x-%04x-%04x-%012x", &v1, &v2, &v3, &v4, &v5)
+const hexDigits = "0123456789abcdef"
+
+// Write the 36 character text form of u to dst, which must be at least that long.
+func encodeHex(dst []byte, u UUID) {
+	j := 0
+	for i, c := range u {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			dst[j] = '-'
+			j++
+		}
+		dst[j], dst[j+1] = hexDigits[c>>4], hexDigits[c&0x0F]
+		j += 2
+	}
+}
+
+// ParseOption is an extra check made by Parse.
+type ParseOption func(u UUID) error
+
+// RequireValid makes Parse return an error for UUIDs that are not IsValid.
+func RequireValid() ParseOption {
+	return func(u UUID) error {
+		if err := u.validate(); err != nil {
+			return fmt.Errorf("invalid UUID %v: %v", u, err)
+		}
+		return nil
+	}
+}
+
+// Parse the text form of a UUID leniently, for reading IDs from logs and
+// other loosely formatted sources: surrounding whitespace, a "urn:uuid:"
+// prefix, surrounding braces, the compact form without dashes and either
+// case are all accepted, and any version (see ParseStrict).  opts can add
+// further checks.
+func Parse(us string, opts ...ParseOption) (UUID, error) {
+	ret, err := parse(us)
 	if err != nil {
 		return ret, err
 	}
-	bigEnd.PutUint64(ret[8:], v5)
-	bigEnd.PutUint16(ret[8:10], uint16(v4))
-	bigEnd.PutUint16(ret[6:8], uint16(v3))
-	bigEnd.PutUint16(ret[4:6], uint16(v2))
-	bigEnd.PutUint32(ret[:4], uint32(v1))
+	for _, opt := range opts {
+		if err := opt(ret); err != nil {
+			return UUID{}, err
+		}
+	}
+	return ret, nil
+}
+
+func parse(us string) (UUID, error) {
+
+	s := strings.TrimSpace(us)
+	if len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	}
+	if len(s) > 2 && s[0] == '{' && s[len(s)-1] == '}' {
+		s = s[1 : len(s)-1]
+	}
+	if len(s) == 32 {
+		s = s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+	}
+
+	var ret UUID
+	if ret.DecodeText([]byte(s)) != nil {
+		return UUID{}, fmt.Errorf("gouuidv6: invalid UUID %q", us)
+	}
 	return ret, nil
 }
 
+// EncodeText appends the text form of the UUID to dst and returns the
+// extended buffer.  It does not allocate if dst has room for 36 more bytes.
+func (u UUID) EncodeText(dst []byte) []byte {
+	dst = append(dst, make([]byte, 36)...)
+	encodeHex(dst[len(dst)-36:], u)
+	return dst
+}
+
+// DecodeText sets the UUID from its 36 character text form in src, in
+// either case, without allocating.
+func (u *UUID) DecodeText(src []byte) error {
+	if len(src) != 36 || src[8] != '-' || src[13] != '-' || src[18] != '-' || src[23] != '-' {
+		return fmt.Errorf("invalid UUID text %q", src)
+	}
+	var ret UUID
+	j := 0
+	for i := range ret {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			j++
+		}
+		hi, ok1 := unhex(src[j])
+		lo, ok2 := unhex(src[j+1])
+		if !ok1 || !ok2 {
+			return fmt.Errorf("invalid UUID text %q", src)
+		}
+		ret[i] = hi<<4 | lo
+		j += 2
+	}
+	*u = ret
+	return nil
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
 func (u UUID) MarshalText() ([]byte, error)           { return []byte(u.String()), nil }
 func (u *UUID) UnmarshalText(text []byte) (err error) { *u, err = Parse(string(text)); return }
 
 func (u UUID) MarshalBinary() ([]byte, error)     { return u[:], nil }
 func (u *UUID) UnmarshalBinary(data []byte) error { copy(u[:], data); return nil }
 
-func (u UUID) MarshalJSON() ([]byte, error) { return []byte(`"` + u.String() + `"`), nil }
+func (u UUID) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 38)
+	b[0], b[37] = '"', '"'
+	encodeHex(b[1:37], u)
+	return b, nil
+}
+
 func (u *UUID) UnmarshalJSON(data []byte) error {
 	s := ""
 	err := json.Unmarshal(data, &s)
@@ -89,112 +196,323 @@ func (u UUID) Bytes() []byte { return u[:] }
 // Return true if all UUID bytes are zero.
 func (u UUID) IsNil() bool { return (bigEnd.Uint64(u[0:8]) | bigEnd.Uint64(u[8:16])) == 0 }
 
+// Return the version number from the UUID (6 for UUIDs created by this package).
+func (u UUID) Version() int { return int(u[6] >> 4) }
+
+// Return the variant from the UUID, following the bit patterns in RFC 4122
+// section 4.1.1: 0 (NCS), 2 (RFC 4122), 6 (Microsoft) or 7 (reserved).
+func (u UUID) Variant() int {
+	switch {
+	case u[8]&0x80 == 0:
+		return 0
+	case u[8]&0xC0 == 0x80:
+		return 2
+	}
+	return int(u[8] >> 5)
+}
+
+// Return the 14-bit clock sequence from the UUID.
+func (u UUID) ClockSeq() uint16 { return bigEnd.Uint16(u[8:10]) & 0x3fff }
+
+// Return the 48-bit node from the UUID, in the same form as a MAC address.
+// Node is small enough to be inlined, so when the result does not outlive the
+// caller it is not allocated on the heap.
+func (u UUID) Node() net.HardwareAddr {
+	n := make(net.HardwareAddr, 6)
+	copy(n, u[10:])
+	return n
+}
+
+// Return true if the node has the multicast bit set, which RFC 4122 section
+// 4.5 reserves for nodes that are not a real MAC address (such as the random
+// nodes this package uses by default).  False means the node is probably the
+// MAC address of the machine that created the UUID.
+func (u UUID) IsRandomNode() bool { return u[10]&0x01 != 0 }
+
+// Return true if the version and variant fields are those of a "Version 6"
+// UUID, and its time is plausible: not before the Unix epoch and not more
+// than a year in the future by the clock of the default Generator (see
+// SetTimeFunc).  UUIDs with other times are almost always corrupted data or
+// not really version 6.
+func (u UUID) IsValid() bool { return u.validate() == nil }
+
+// How far in the future the time of a UUID may be for IsValid.
+const maxFutureTime = 365 * 24 * time.Hour
+
+// Return why the UUID is not valid, or nil if it is.
+func (u UUID) validate() error {
+	if !u.isV6() {
+		return fmt.Errorf("not a version 6 UUID")
+	}
+	ts := u.timestamp()
+	if ts < tsoff {
+		return fmt.Errorf("time %v is before 1970", tsToTime(ts).UTC())
+	}
+	if ts > tstime(defaultNow().Add(maxFutureTime)) {
+		return fmt.Errorf("time %v is too far in the future", tsToTime(ts).UTC())
+	}
+	return nil
+}
+
+// Return true if the version and variant fields are those of a "Version 6" UUID.
+func (u UUID) isV6() bool { return (u[6]&0xF0) == 0x60 && (u[8]&0xC0) == 0x80 }
+
 // Extract and return the time from the UUID.
 func (u UUID) Time() time.Time {
 
 	// verify version and variant fields
-	if !((u[6]&0xF0) == 0x60 && (u[8]&0xC0) == 0x80) {
+	if !u.isV6() {
 		return time.Time{} // return zero time if not a version 6 UUID
 	}
 
-	hi := uint64(bigEnd.Uint64(u[:8]))
-
-	// chop the version data out and form the number we want
-	t := ((hi >> 4) & 0xFFFFFFFFFFFFF000) | (0x0FFF & hi)
+	return tsToTime(u.timestamp())
+}
 
-	// convert to nanoseconds
-	ut := int64(t-tsoff) * 100
+// Extract and return the time from the UUID, and whether the UUID is a
+// version 6 UUID.  Unlike Time, this distinguishes an invalid UUID from one
+// that has the zero time.
+func (u UUID) TimeOK() (time.Time, bool) {
+	if !u.isV6() {
+		return time.Time{}, false
+	}
+	return tsToTime(u.timestamp()), true
+}
 
-	return time.Unix(ut/int64(time.Second), ut%int64(time.Second))
+// Return the time of the UUID in UTC, truncated to a multiple of granularity
+// and formatted with layout, e.g. "2024-06-01T12" for time.Hour and
+// "2006-01-02T15".  Useful for writing records to time partitioned tables or
+// object store paths keyed by their ID.  Returns "" if the UUID is not
+// version 6.
+func (u UUID) PartitionKey(granularity time.Duration, layout string) string {
+	t, ok := u.TimeOK()
+	if !ok {
+		return ""
+	}
+	return t.UTC().Truncate(granularity).Format(layout)
 }
 
-func NewFromTime(t time.Time) UUID {
+// Return the time of the UUID as the number of milliseconds since the Unix
+// epoch, without converting to a time.Time.  Returns 0 if the UUID is not
+// version 6 or its time is before 1970.
+func (u UUID) UnixMilli() int64 { return int64(u.Timestamp100ns() / 10000) }
 
-	// NOTE: We intentionally ignore RFC 4122 section 4.2.1.2. and in the case
-	// that UUIDs are requested within the same 100-nanosecond time interval,
-	// we just increment the clock sequence - the same thing the RFC advises
-	// in the case of the clock moving backward (section 4.1.5).
+// Return the time of the UUID as the number of microseconds since the Unix
+// epoch; see UnixMilli.
+func (u UUID) UnixMicro() int64 { return int64(u.Timestamp100ns() / 10) }
 
-	// get current timestamp
-	tsval := tstime(t)
+// Return the time of the UUID as the number of 100-nanosecond intervals (the
+// precision it is stored with) since the Unix epoch; see UnixMilli.
+func (u UUID) Timestamp100ns() uint64 {
+	if !u.isV6() {
+		return 0
+	}
+	t := u.timestamp()
+	if t < tsoff {
+		return 0
+	}
+	return t - tsoff
+}
 
-	newlock.Lock()
-	// if clock is the same as last time or moved backward, increment clockseq
-	if lastts >= tsval {
-		clockseq++
+// Return the raw 60-bit timestamp of the UUID: the number of 100-nanosecond
+// intervals since the start of the Gregorian calendar (15 October 1582), as
+// used by version 1 UUIDs, Cassandra timeuuids and the like.  Version 1 UUIDs
+// are also understood; for other versions it returns 0.
+func (u UUID) GregorianTimestamp() uint64 {
+	switch u.Version() {
+	case 6:
+		return u.timestamp()
+	case 1:
+		v6, _ := FromV1(u)
+		return v6.timestamp()
 	}
-	lastts = tsval
-	cs := clockseq
-	newlock.Unlock()
+	return 0
+}
 
+// Return a version 6 UUID from a raw 60-bit Gregorian timestamp (see
+// GregorianTimestamp), 14-bit clock sequence and 48-bit node.  Higher bits of
+// each are ignored.
+func FromGregorianTimestamp(ts uint64, clockseq uint16, node uint64) UUID {
 	var ret UUID
+	bigEnd.PutUint64(ret[:8], tshi(ts&0x0FFFFFFFFFFFFFFF))
+	bigEnd.PutUint64(ret[8:], (uint64(0x8000)<<48)|(uint64(clockseq&0x3fff)<<48)|(node&0x0000FFFFFFFFFFFF))
+	return ret
+}
 
-	// shift up 4 bits, mask back in the relevant lower part and set the version
-	hi := uint64(((tsval << 4) & 0xFFFFFFFFFFFF0000) | (tsval & 0x0FFF) | 0x6000)
+// Return the 60-bit timestamp from the UUID (without checking the version).
+func (u UUID) timestamp() uint64 {
 
-	// 2 bit variant, 14 bits clock sequence, 48 bits node
-	lo := (uint64(0x8000) << 48) | (uint64(cs&0x3fff) << 48) | node
+	hi := uint64(bigEnd.Uint64(u[:8]))
 
-	bigEnd.PutUint64(ret[:8], hi)
-	bigEnd.PutUint64(ret[8:], lo)
+	// chop the version data out and form the number we want
+	return ((hi >> 4) & 0xFFFFFFFFFFFFF000) | (0x0FFF & hi)
+}
 
-	return ret
+// Return a new UUID with the time t, using the default Generator.
+func NewFromTime(t time.Time) UUID { return defaultGenerator.NewFromTime(t) }
+
+// Return a new UUID initialized to a proper value according to "Version 6" rules.
+func New() UUID { return defaultGenerator.New() }
 
+// Write n new UUIDs to w using the default Generator; see Generator.WriteN.
+func WriteN(w io.Writer, n int, sep byte) error { return defaultGenerator.WriteN(w, n, sep) }
+
+// Return the lowest possible UUID with the time t (zero clock sequence and
+// node), which sorts before any UUID created at or after t.  Useful as the
+// start of a range query.
+func FirstForTime(t time.Time) UUID {
+	var ret UUID
+	bigEnd.PutUint64(ret[:8], tshi(tstime(t)))
+	bigEnd.PutUint64(ret[8:], uint64(0x8000)<<48)
+	return ret
 }
 
-// Return a new UUID initialized to a proper value according to "Version 6" rules.
-func New() UUID { return NewFromTime(time.Now()) }
+// Return the highest possible UUID with the time t (all clock sequence and
+// node bits set), which sorts after any UUID created at or before t.  Useful
+// as the end of a range query.
+func LastForTime(t time.Time) UUID {
+	var ret UUID
+	bigEnd.PutUint64(ret[:8], tshi(tstime(t)))
+	bigEnd.PutUint64(ret[8:], 0xBFFFFFFFFFFFFFFF)
+	return ret
+}
 
-// Returns a timestamp appropriate for UUID time
-func ts() uint64 { return tsoff + uint64(time.Now().UnixNano()/100) }
+// Shift the timestamp up 4 bits, mask back in the relevant lower part and set
+// the version, giving the first 64 bits of the UUID.
+func tshi(tsval uint64) uint64 {
+	return ((tsval << 4) & 0xFFFFFFFFFFFF0000) | (tsval & 0x0FFF) | 0x6000
+}
 
 func tstime(t time.Time) uint64 { return tsoff + uint64(t.UnixNano()/100) }
 
+// Convert a UUID timestamp back to a time.Time, the inverse of tstime.
+func tsToTime(t uint64) time.Time {
+
+	// convert to nanoseconds
+	ut := int64(t-tsoff) * 100
+
+	return time.Unix(ut/int64(time.Second), ut%int64(time.Second))
+}
+
 // UUID static time offset (see https://play.golang.org/p/pPJd86iZMW)
 const tsoff = uint64(122192928000000000)
 
-// lock we use when creating new UUIDs
-var newlock sync.Mutex
+// the Generator used by the package level functions
+var defaultGenerator = &Generator{now: defaultNow, csSize: 0x4000}
 
-// last timestamp used
-var lastts uint64
+// the time function set with SetTimeFunc, nil for time.Now
+var timeFunc atomic.Pointer[func() time.Time]
+
+func defaultNow() time.Time {
+	if fn := timeFunc.Load(); fn != nil {
+		return (*fn)()
+	}
+	return time.Now()
+}
 
-// clock sequence value (32-bit so we can use sync/atomic)
-var clockseq uint32
+// SetTimeFunc sets the function the default Generator calls to get the
+// current time, as WithTimeFunc does for other Generators, so tests and
+// simulations can control the time of UUIDs created with New.
+func SetTimeFunc(now func() time.Time) {
+	if now == nil {
+		ResetTimeFunc()
+		return
+	}
+	timeFunc.Store(&now)
+}
 
-// the node part - based on interface MAC address or random
-var node uint64
+// ResetTimeFunc makes the default Generator use time.Now again.
+func ResetTimeFunc() { timeFunc.Store(nil) }
 
 func init() {
 
-	b := make([]byte, 8)
-
 	// start with random clock sequence
-	rand.Read(b)
-	clockseq = bigEnd.Uint32(b[:4])
-
-	// try to get first interface MAC and use that for node
-	ifs, _ := net.Interfaces()
-	for _, i := range ifs {
-		if len(i.HardwareAddr) >= 6 {
-			node = uint64(bigEnd.Uint16(i.HardwareAddr[:2]))<<32 | uint64(bigEnd.Uint32(i.HardwareAddr[2:6]))
-			break
-		}
+	cs, err := randUint64()
+	if err != nil {
+		defaultGenerator.counters.entropyFailures.Add(1)
 	}
+	defaultGenerator.setClock(clockState{clockseq: uint32(cs)})
 
-	// no node yet, make it random
-	if node == 0 {
+	// random node, unless the MAC address has been asked for in the
+	// environment (the default before UseMACNode was added)
+	if b, _ := strconv.ParseBool(os.Getenv(EnvMACNode)); !b || UseMACNode() != nil {
 		RandomizeNode()
 	}
 
 }
 
-// Set the 'node' part of the UUID to a random value, instead of using one
-// of the MAC addresses from the system.  Use this if you are concerned about
-// the privacy aspect of using a MAC address.
+// Set the 'node' part of the UUID to a new random value.  A random node is
+// used by default.
 func RandomizeNode() {
-	b := make([]byte, 8)
-	rand.Read(b)
+	node, _ := defaultGenerator.randomNode()
+	defaultGenerator.node.Store(node)
+}
+
+// Set the 'node' part of the UUID to the first MAC address of the system, as
+// RFC 4122 intends.  This is not the default because it reveals the hardware
+// address in every UUID; see also UseHashedMACNode.  Returns an error if the
+// system has no MAC address.
+func UseMACNode() error {
+	mac := firstMAC()
+	if mac == nil {
+		return fmt.Errorf("gouuidv6: no network interface with a hardware address")
+	}
+	defaultGenerator.node.Store(macNode(mac))
+	return nil
+}
+
+// Set the 'node' part of the UUID to a hash of the first MAC address of the
+// system, as suggested by RFC 9562 section 6.10, giving a node that is stable
+// for the host without revealing the hardware address.  Returns an error if
+// the system has no MAC address.
+func UseHashedMACNode() error {
+	mac := firstMAC()
+	if mac == nil {
+		return fmt.Errorf("gouuidv6: no network interface with a hardware address")
+	}
+	defaultGenerator.node.Store(HashedMACNode(mac, nil))
+	return nil
+}
+
+// HashedMACNode returns a node derived from the SHA-256 hash of mac, or the
+// HMAC-SHA-256 with key if key is not nil, with the multicast bit set, for use
+// with WithNode.  Using a secret key prevents the MAC address being recovered
+// by hashing candidate addresses.
+func HashedMACNode(mac net.HardwareAddr, key []byte) uint64 {
+	var sum []byte
+	if key != nil {
+		h := hmac.New(sha256.New, key)
+		h.Write(mac)
+		sum = h.Sum(nil)
+	} else {
+		s := sha256.Sum256(mac)
+		sum = s[:]
+	}
+	return macNode(sum) | 0x0000010000000000
+}
+
+// Return a snapshot of the default Generator's counters.
+func Metrics() GeneratorMetrics { return defaultGenerator.Metrics() }
+
+// Return a random 48-bit node with the multicast bit set (RFC 4122 section 4.5).
+func randomNode() (uint64, error) {
+	r, err := randUint64()
 	// mask out high 2 bytes and set the multicast bit
-	node = (bigEnd.Uint64(b[:8]) & 0x0000FFFFFFFFFFFF) | 0x0000010000000000
+	return (r & 0x0000FFFFFFFFFFFF) | 0x0000010000000000, err
+}
+
+// Return the hardware address of the first network interface that has one,
+// or nil.
+func firstMAC() net.HardwareAddr {
+	ifs, _ := net.Interfaces()
+	for _, i := range ifs {
+		if len(i.HardwareAddr) >= 6 {
+			return i.HardwareAddr
+		}
+	}
+	return nil
+}
+
+// Return the first 6 bytes of a MAC address as a 48-bit node.
+func macNode(mac net.HardwareAddr) uint64 {
+	return uint64(bigEnd.Uint16(mac[:2]))<<32 | uint64(bigEnd.Uint32(mac[2:6]))
 }
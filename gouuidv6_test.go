@@ -1,7 +1,9 @@
 package gouuidv6
 
 import (
+	"bytes"
 	"encoding/json"
+	"net"
 	"runtime"
 	"sort"
 	"strings"
@@ -27,6 +29,10 @@ func TestUUIDSimple(t *testing.T) {
 		t.Fatalf("Version number was not 6! (offending byte: %02x)", uuid[7])
 	}
 
+	if !uuid.IsValid() {
+		t.Fatalf("New UUID should be valid but was not: %v", uuid)
+	}
+
 	uuid = New()
 	tim := time.Now()
 
@@ -42,6 +48,14 @@ func TestUUIDSimple(t *testing.T) {
 		t.Fatalf("String conversion did not get expected value, wanted %q, got %q", str, uuid.String())
 	}
 
+	if uuid.IsValid() {
+		t.Fatalf("Version 1 UUID should not be valid: %v", uuid)
+	}
+
+	if uuid.Version() != 1 || uuid.Variant() != 2 || uuid.ClockSeq() != 0x2765 || uuid.Node().String() != "00:a0:c9:1e:6b:f6" {
+		t.Fatalf("Fields not extracted correctly from %v: version=%d variant=%d clockseq=%d node=%v", uuid, uuid.Version(), uuid.Variant(), uuid.ClockSeq(), uuid.Node())
+	}
+
 	// example of uuidv6 generated from another source (and manually pasted in here)
 	str2 := `1E65DA3A-36E8-617E-9FCC-C8BCC8A0B17D`
 	uuid2, _ := Parse(str2)
@@ -194,3 +208,264 @@ func TestSort(t *testing.T) {
 	}
 
 }
+
+func TestFirstLastForTime(t *testing.T) {
+
+	tim := time.Now()
+	u := NewFromTime(tim)
+
+	first, last := FirstForTime(tim), LastForTime(tim)
+
+	if !first.IsValid() || !last.IsValid() {
+		t.Fatalf("Range bounds should be valid UUIDs: %v, %v", first, last)
+	}
+
+	if !first.Time().Equal(u.Time()) || !last.Time().Equal(u.Time()) {
+		t.Fatalf("Range bounds should have the same time as %v: %v, %v", u, first, last)
+	}
+
+	if bytes.Compare(first[:], u[:]) > 0 || bytes.Compare(last[:], u[:]) < 0 {
+		t.Fatalf("%v does not fall between %v and %v", u, first, last)
+	}
+
+	if next := FirstForTime(tim.Add(100 * time.Nanosecond)); bytes.Compare(last[:], next[:]) >= 0 {
+		t.Fatalf("LastForTime %v should sort before the next tick %v", last, next)
+	}
+
+}
+
+func TestMarshalJSONAllocs(t *testing.T) {
+
+	u := New()
+	if n := testing.AllocsPerRun(100, func() { u.MarshalJSON() }); n > 1 {
+		t.Fatalf("Expected at most 1 allocation for MarshalJSON, got %v", n)
+	}
+
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	u := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		u.MarshalJSON()
+	}
+}
+
+func TestEncodeDecodeText(t *testing.T) {
+
+	u := New()
+
+	buf := make([]byte, 0, 64)
+	buf = u.EncodeText(buf)
+	buf = append(buf, ',')
+	buf = u.EncodeText(buf)
+	if string(buf) != u.String()+","+u.String() {
+		t.Fatalf("Unexpected EncodeText output %q", buf)
+	}
+
+	var u2 UUID
+	if err := u2.DecodeText(buf[37:]); err != nil {
+		t.Fatal(err)
+	}
+	if u2 != u {
+		t.Fatalf("Expected %v from DecodeText, got %v", u, u2)
+	}
+
+	if err := u2.DecodeText([]byte(`1E65DA3A-36E8-617E-9FCC-C8BCC8A0B17D`)); err != nil || u2.String() != `1e65da3a-36e8-617e-9fcc-c8bcc8a0b17d` {
+		t.Fatalf("Expected upper case text to decode, got %v (%v)", u2, err)
+	}
+
+	for _, s := range []string{``, `1e65da3a36e8617e9fccc8bcc8a0b17d`, `1e65da3a-36e8-617e-9fcc-c8bcc8a0b17g`} {
+		if err := u2.DecodeText([]byte(s)); err == nil {
+			t.Fatalf("Expected error decoding %q", s)
+		}
+	}
+
+	buf = buf[:0]
+	if n := testing.AllocsPerRun(100, func() { buf = u.EncodeText(buf[:0]); u2.DecodeText(buf) }); n > 0 {
+		t.Fatalf("Expected no allocations, got %v", n)
+	}
+
+}
+
+func TestNodeAllocs(t *testing.T) {
+
+	u := New()
+	if n := testing.AllocsPerRun(100, func() {
+		if u.Node()[0] == 0xff && u.Node()[5] == 0xff {
+			t.Log("unlikely node")
+		}
+	}); n > 0 {
+		t.Fatalf("Expected no allocations for Node, got %v", n)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { randomNode() }); n > 0 {
+		t.Fatalf("Expected no allocations for randomNode, got %v", n)
+	}
+
+}
+
+func BenchmarkNode(b *testing.B) {
+	u := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if u.Node()[0] == 0xff {
+			b.Log("unlikely node")
+		}
+	}
+}
+
+func BenchmarkRandomNode(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		randomNode()
+	}
+}
+
+func TestHashedMACNode(t *testing.T) {
+
+	mac, _ := net.ParseMAC("00:a0:c9:1e:6b:f6")
+
+	n := HashedMACNode(mac, nil)
+	if n != HashedMACNode(mac, nil) {
+		t.Fatalf("Expected the same node for the same MAC")
+	}
+	if n&0x0000010000000000 == 0 {
+		t.Fatalf("Expected multicast bit set in node %x", n)
+	}
+	if n == macNode(mac) {
+		t.Fatalf("Expected the node not to be the MAC address")
+	}
+	if k := HashedMACNode(mac, []byte("secret")); k == n || k != HashedMACNode(mac, []byte("secret")) {
+		t.Fatalf("Expected a different but stable node with a key, got %x", k)
+	}
+
+}
+
+func TestDefaultNode(t *testing.T) {
+
+	if n := New().Node(); n[0]&0x01 == 0 {
+		t.Fatalf("Expected random default node with multicast bit set, got %v", n)
+	}
+
+	mac := firstMAC()
+	if mac == nil {
+		if err := UseMACNode(); err == nil {
+			t.Fatalf("Expected error from UseMACNode with no MAC address")
+		}
+		return
+	}
+	defer RandomizeNode()
+
+	if err := UseMACNode(); err != nil {
+		t.Fatal(err)
+	}
+	if n := New().Node(); !bytes.Equal(n, mac[:6]) {
+		t.Fatalf("Expected MAC node %v, got %v", mac, n)
+	}
+
+}
+
+func TestIsRandomNode(t *testing.T) {
+
+	if u := New(); !u.IsRandomNode() {
+		t.Fatalf("Expected random node in %v", u)
+	}
+
+	u, _ := Parse(`1d07decf-81d4-6fae-a765-00a0c91e6bf6`)
+	if u.IsRandomNode() {
+		t.Fatalf("Expected MAC node in %v", u)
+	}
+
+}
+
+func TestSetTimeFunc(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	SetTimeFunc(func() time.Time { return tim })
+	defer ResetTimeFunc()
+
+	if u := New(); !u.Time().Equal(tim) {
+		t.Fatalf("Expected time %v, got %v", tim, u.Time())
+	}
+	if u := NewB64(); !u.Time().Equal(tim) {
+		t.Fatalf("Expected time %v from NewB64, got %v", tim, u.Time())
+	}
+
+	ResetTimeFunc()
+	if u := New(); u.Time().Before(time.Now().Add(-time.Minute)) {
+		t.Fatalf("Expected the current time after reset, got %v", u.Time())
+	}
+
+}
+
+func TestTimeOK(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if ut, ok := NewFromTime(tim).TimeOK(); !ok || !ut.Equal(tim) {
+		t.Fatalf("Expected %v, true; got %v, %v", tim, ut, ok)
+	}
+
+	v1, _ := Parse(`f81d4fae-7dec-11d0-a765-00a0c91e6bf6`)
+	if ut, ok := v1.TimeOK(); ok || !ut.IsZero() {
+		t.Fatalf("Expected zero time and false for a version 1 UUID, got %v, %v", ut, ok)
+	}
+
+}
+
+func TestUnixTimestamps(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 123456700, time.UTC)
+	u := NewFromTime(tim)
+
+	if u.UnixMilli() != tim.UnixMilli() || u.UnixMicro() != tim.UnixMicro() || u.Timestamp100ns() != uint64(tim.UnixNano()/100) {
+		t.Fatalf("Unexpected timestamps %d, %d, %d for %v", u.UnixMilli(), u.UnixMicro(), u.Timestamp100ns(), tim)
+	}
+
+	v1, _ := Parse(`f81d4fae-7dec-11d0-a765-00a0c91e6bf6`)
+	if v1.UnixMilli() != 0 || NewFromTime(time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)).Timestamp100ns() != 0 {
+		t.Fatalf("Expected 0 for a version 1 UUID and a time before 1970")
+	}
+
+}
+
+func TestValidTimeBounds(t *testing.T) {
+
+	if u := NewFromTime(time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)); u.IsValid() {
+		t.Fatalf("Expected a UUID from before 1970 to be invalid: %v", u)
+	}
+	if u := NewFromTime(time.Now().Add(2 * 365 * 24 * time.Hour)); u.IsValid() {
+		t.Fatalf("Expected a UUID from two years in the future to be invalid: %v", u)
+	}
+	if u := NewFromTime(time.Now().Add(time.Hour)); !u.IsValid() {
+		t.Fatalf("Expected a UUID from an hour in the future to be valid: %v", u)
+	}
+
+	old := FirstForTime(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)).String()
+	if _, err := Parse(old); err != nil {
+		t.Fatalf("Expected Parse to accept %s without options: %v", old, err)
+	}
+	if _, err := Parse(old, RequireValid()); err == nil {
+		t.Fatalf("Expected Parse with RequireValid to reject %s", old)
+	}
+	if _, err := Parse(New().String(), RequireValid()); err != nil {
+		t.Fatal(err)
+	}
+
+}
+
+func TestPartitionKey(t *testing.T) {
+
+	u := NewFromTime(time.Date(2024, 6, 1, 12, 34, 56, 0, time.UTC))
+
+	if k := u.PartitionKey(time.Hour, "2006-01-02T15"); k != "2024-06-01T12" {
+		t.Fatalf("Unexpected hourly partition key %q", k)
+	}
+	if k := u.PartitionKey(15*time.Minute, "2006/01/02/15-04"); k != "2024/06/01/12-30" {
+		t.Fatalf("Unexpected 15 minute partition key %q", k)
+	}
+	if k := (UUID{}).PartitionKey(time.Hour, "2006-01-02T15"); k != "" {
+		t.Fatalf("Expected no partition key for an invalid UUID, got %q", k)
+	}
+
+}
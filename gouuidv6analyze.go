@@ -0,0 +1,115 @@
+package gouuidv6
+
+import (
+	"sort"
+	"time"
+)
+
+// Analysis is a summary of a collection of UUIDs made by Analyze, for data
+// quality checks.
+type Analysis struct {
+	Count           int           // valid UUIDs, including duplicates
+	Invalid         int           // UUIDs that are not IsValid, otherwise ignored
+	Duplicates      int           // UUIDs equal to an earlier one
+	DuplicateValues int           // distinct values that are duplicated
+	Nodes           int           // distinct nodes
+	First, Last     time.Time     // earliest and latest time
+	Span            time.Duration // Last - First
+	OutOfOrder      float64       // fraction of UUIDs that sort before the one preceding them
+	// Distinct UUIDs with the same time and node as another, so unique only
+	// by their clock sequence.
+	SharedTicks int
+	// Expected number of collisions among SharedTicks if their clock
+	// sequences were random, as they are after restarts, rather than counted
+	// by a single Generator.
+	CollisionEstimate float64
+	// Non-empty time buckets in order, if Analyze was given a bucket size.
+	Buckets []Bucket
+}
+
+// Bucket is the number of UUIDs with a time in [Start, Start+bucket size).
+type Bucket struct {
+	Start time.Time
+	Count int
+}
+
+// Analyze returns an Analysis of uuids, with a count of UUIDs for each
+// period of bucket length (from the Unix epoch) if bucket is positive.
+func Analyze(uuids []UUID, bucket time.Duration) Analysis {
+
+	var a Analysis
+	seen := make(map[UUID]int, len(uuids))
+	nodes := make(map[uint64]bool)
+	ticks := make(map[[2]uint64]int)
+	buckets := make(map[int64]int)
+	var prev UUID
+	outOfOrder := 0
+
+	for _, u := range uuids {
+
+		if !u.IsValid() {
+			a.Invalid++
+			continue
+		}
+
+		if a.Count > 0 && less(u, prev) {
+			outOfOrder++
+		}
+		prev = u
+		a.Count++
+
+		if seen[u]++; seen[u] > 1 {
+			a.Duplicates++
+			if seen[u] == 2 {
+				a.DuplicateValues++
+			}
+			continue
+		}
+
+		node := bigEnd.Uint64(u[8:]) & 0x0000FFFFFFFFFFFF
+		nodes[node] = true
+		ticks[[2]uint64{u.timestamp(), node}]++
+
+		t := u.Time()
+		if a.First.IsZero() || t.Before(a.First) {
+			a.First = t
+		}
+		if t.After(a.Last) {
+			a.Last = t
+		}
+		if bucket > 0 {
+			buckets[t.UnixNano()/int64(bucket)]++
+		}
+	}
+
+	// duplicates count towards the buckets too
+	if bucket > 0 {
+		for u, c := range seen {
+			if c > 1 {
+				buckets[u.Time().UnixNano()/int64(bucket)] += c - 1
+			}
+		}
+	}
+
+	a.Nodes = len(nodes)
+	a.Span = a.Last.Sub(a.First)
+	if a.Count > 1 {
+		a.OutOfOrder = float64(outOfOrder) / float64(a.Count-1)
+	}
+	for _, k := range ticks {
+		if k > 1 {
+			a.SharedTicks += k
+			a.CollisionEstimate += float64(k) * float64(k-1) / 2 / 0x4000
+		}
+	}
+
+	if bucket > 0 {
+		a.Buckets = make([]Bucket, 0, len(buckets))
+		for k, c := range buckets {
+			a.Buckets = append(a.Buckets, Bucket{Start: time.Unix(0, k*int64(bucket)).UTC(), Count: c})
+		}
+		sort.Slice(a.Buckets, func(i, j int) bool { return a.Buckets[i].Start.Before(a.Buckets[j].Start) })
+	}
+
+	return a
+}
@@ -0,0 +1,51 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+
+	g := NewGenerator(WithNode(1))
+	g2 := NewGenerator(WithNode(2))
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var uuids []UUID
+	for i := 0; i < 5; i++ {
+		uuids = append(uuids, g.NewFromTime(start.Add(time.Duration(i)*time.Second)))
+	}
+	uuids = append(uuids, g2.NewFromTime(start.Add(90*time.Second)))
+	uuids = append(uuids, g2.NewFromTime(start.Add(90*time.Second))) // same tick
+	uuids = append(uuids, uuids[0], UUID{})                          // duplicate, invalid
+
+	a := Analyze(uuids, time.Minute)
+
+	if a.Count != 8 || a.Invalid != 1 || a.Duplicates != 1 || a.DuplicateValues != 1 || a.Nodes != 2 {
+		t.Fatalf("Unexpected counts %+v", a)
+	}
+	if !a.First.Equal(start) || a.Span != 90*time.Second {
+		t.Fatalf("Expected span 1m30s from %v, got %v from %v", start, a.Span, a.First)
+	}
+	if a.OutOfOrder != 1.0/7 {
+		t.Fatalf("Expected 1 of 7 out of order, got %v", a.OutOfOrder)
+	}
+	if a.SharedTicks != 2 || a.CollisionEstimate != 1.0/0x4000 {
+		t.Fatalf("Expected 2 shared ticks, got %d (estimate %v)", a.SharedTicks, a.CollisionEstimate)
+	}
+
+	want := []Bucket{{start, 6}, {start.Add(time.Minute), 2}}
+	if len(a.Buckets) != len(want) {
+		t.Fatalf("Expected buckets %v, got %v", want, a.Buckets)
+	}
+	for i := range want {
+		if !a.Buckets[i].Start.Equal(want[i].Start) || a.Buckets[i].Count != want[i].Count {
+			t.Fatalf("Expected buckets %v, got %v", want, a.Buckets)
+		}
+	}
+
+	if a := Analyze(nil, 0); a.Count != 0 || a.Buckets != nil {
+		t.Fatalf("Expected empty analysis, got %+v", a)
+	}
+
+}
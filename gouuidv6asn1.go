@@ -0,0 +1,54 @@
+package gouuidv6
+
+import (
+	"encoding/asn1"
+	"fmt"
+)
+
+// The encoding/asn1 package has no marshaler interface for types to
+// implement and cannot encode arrays, so a UUID has to be converted to and
+// from DER explicitly.  These use an OCTET STRING of the 16 bytes, the usual
+// way to carry a UUID in X.509 extensions, LDAP entries and other DER based
+// protocols.
+
+// MarshalASN1 returns the UUID DER encoded as an OCTET STRING, for the Value
+// of a pkix.Extension and the like.
+func (u UUID) MarshalASN1() ([]byte, error) { return asn1.Marshal(u[:]) }
+
+// UnmarshalASN1 sets the UUID from a DER encoded OCTET STRING of 16 bytes,
+// as from MarshalASN1.
+func (u *UUID) UnmarshalASN1(der []byte) error {
+	var b []byte
+	rest, err := asn1.Unmarshal(der, &b)
+	if err != nil {
+		return fmt.Errorf("gouuidv6: invalid ASN.1 UUID: %v", err)
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("gouuidv6: %d bytes of trailing data after ASN.1 UUID", len(rest))
+	}
+	if len(b) != 16 {
+		return fmt.Errorf("gouuidv6: ASN.1 OCTET STRING of %d bytes is not a UUID", len(b))
+	}
+	copy(u[:], b)
+	return nil
+}
+
+// ASN1RawValue returns the UUID as an OCTET STRING for an asn1.RawValue field
+// of a struct passed to asn1.Marshal.
+func (u UUID) ASN1RawValue() asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagOctetString, Bytes: u[:]}
+}
+
+// FromASN1RawValue returns the UUID in an asn1.RawValue field filled in by
+// asn1.Unmarshal, which must be a universal OCTET STRING of 16 bytes.
+func FromASN1RawValue(v asn1.RawValue) (UUID, error) {
+	var u UUID
+	if v.Class != asn1.ClassUniversal || v.Tag != asn1.TagOctetString || v.IsCompound {
+		return u, fmt.Errorf("gouuidv6: ASN.1 value with class %d and tag %d is not an OCTET STRING", v.Class, v.Tag)
+	}
+	if len(v.Bytes) != 16 {
+		return u, fmt.Errorf("gouuidv6: ASN.1 OCTET STRING of %d bytes is not a UUID", len(v.Bytes))
+	}
+	copy(u[:], v.Bytes)
+	return u, nil
+}
@@ -0,0 +1,74 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+)
+
+func TestASN1(t *testing.T) {
+
+	u := New()
+
+	der, err := u.MarshalASN1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append([]byte{0x04, 0x10}, u[:]...); !bytes.Equal(der, want) {
+		t.Fatalf("Expected DER %x, got %x", want, der)
+	}
+	var u2 UUID
+	if err := u2.UnmarshalASN1(der); err != nil || u2 != u {
+		t.Fatalf("Expected %v, got %v (%v)", u, u2, err)
+	}
+
+	// as the value of an X.509 extension
+	ext := pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Value: der}
+	b, err := asn1.Marshal(ext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ext2 pkix.Extension
+	if _, err := asn1.Unmarshal(b, &ext2); err != nil {
+		t.Fatal(err)
+	}
+	if err := u2.UnmarshalASN1(ext2.Value); err != nil || u2 != u {
+		t.Fatalf("Expected %v from the extension, got %v (%v)", u, u2, err)
+	}
+
+	for _, bad := range [][]byte{
+		{0x04, 0x02, 1, 2},
+		append(der, 0),
+		{0x0C, 0x01, 'x'},
+		nil,
+	} {
+		if err := u2.UnmarshalASN1(bad); err == nil {
+			t.Fatalf("Expected error for %x", bad)
+		}
+	}
+}
+
+func TestASN1RawValue(t *testing.T) {
+
+	type entry struct {
+		Name string
+		ID   asn1.RawValue
+	}
+
+	u := New()
+	b, err := asn1.Marshal(entry{Name: "x", ID: u.ASN1RawValue()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e entry
+	if _, err := asn1.Unmarshal(b, &e); err != nil {
+		t.Fatal(err)
+	}
+	if u2, err := FromASN1RawValue(e.ID); err != nil || u2 != u {
+		t.Fatalf("Expected %v, got %v (%v)", u, u2, err)
+	}
+	if _, err := FromASN1RawValue(asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: u[:]}); err == nil {
+		t.Fatalf("Expected error for a UTF8String")
+	}
+}
@@ -0,0 +1,118 @@
+package gouuidv6
+
+import (
+	"database/sql/driver"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Base32UUIDEncoding is the standard RFC 4648 base32 encoding (upper case
+// A to Z then 2 to 7) without padding, which UUIDB32 uses.
+var Base32UUIDEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// UUIDB32 is like UUID but encodes as 26 characters of upper case base32
+// (Base32UUIDEncoding) for its string value and in the database, for DNS
+// labels, object store keys, file names on case-insensitive file systems
+// and other places where case is not preserved; parsing accepts lower case
+// too.  Note that the standard alphabet does not sort like the bytes it
+// encodes, since the digits, which stand for the highest values, come before
+// the letters in ASCII, so unlike UUIDB64 the strings of UUIDs created one
+// after another do not always sort in the same order.  Sort the UUIDs
+// themselves, or use the base64 form, where order matters.
+type UUIDB32 UUID
+
+// String returns the UUID encoded with Base32UUIDEncoding.
+func (u UUIDB32) String() string {
+	var b [26]byte
+	Base32UUIDEncoding.Encode(b[:], u[:])
+	return string(b[:])
+}
+
+// ParseB32 parses the 26 character base32 form of a UUID, in either case.
+func ParseB32(us string) (UUIDB32, error) {
+	var ret UUIDB32
+	err := ret.DecodeText([]byte(us))
+	return ret, err
+}
+
+// EncodeText appends the base32 form of the UUID to dst and returns the
+// extended buffer.  It does not allocate if dst has room for 26 more bytes.
+func (u UUIDB32) EncodeText(dst []byte) []byte {
+	dst = append(dst, make([]byte, 26)...)
+	Base32UUIDEncoding.Encode(dst[len(dst)-26:], u[:])
+	return dst
+}
+
+// DecodeText sets the UUID from its 26 character base32 form in src, in
+// either case, without allocating.
+func (u *UUIDB32) DecodeText(src []byte) error {
+	if len(src) != 26 {
+		return fmt.Errorf("invalid base32 UUID text %q", src)
+	}
+	var upper [26]byte
+	for i, c := range src {
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper[i] = c
+	}
+	var ret UUIDB32
+	if _, err := Base32UUIDEncoding.Decode(ret[:], upper[:]); err != nil {
+		return fmt.Errorf("invalid base32 UUID text %q", src)
+	}
+	*u = ret
+	return nil
+}
+
+func (u UUIDB32) MarshalText() ([]byte, error)           { return []byte(u.String()), nil }
+func (u UUIDB32) AppendText(b []byte) ([]byte, error)    { return u.EncodeText(b), nil }
+func (u *UUIDB32) UnmarshalText(text []byte) (err error) { return u.DecodeText(text) }
+
+func (u UUIDB32) MarshalBinary() ([]byte, error)     { return u[:], nil }
+func (u *UUIDB32) UnmarshalBinary(data []byte) error { copy(u[:], data); return nil }
+
+func (u UUIDB32) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 28)
+	b[0], b[27] = '"', '"'
+	Base32UUIDEncoding.Encode(b[1:27], u[:])
+	return b, nil
+}
+
+func (u *UUIDB32) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	*u, err = ParseB32(s)
+	return err
+}
+
+func (u UUIDB32) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+func (u *UUIDB32) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return u.DecodeText(v)
+	case string:
+		return u.DecodeText([]byte(v))
+	}
+	return fmt.Errorf("cannot convert from UUIDB32 to sql driver type %T", value)
+}
+
+// Return as byte slice.
+func (u UUIDB32) Bytes() []byte { return u[:] }
+
+// Return true if all UUIDB32 bytes are zero.
+func (u UUIDB32) IsNil() bool { return UUID(u).IsNil() }
+
+// Extract and return the time from the UUIDB32.
+func (u UUIDB32) Time() time.Time { return UUID(u).Time() }
+
+func NewB32FromTime(t time.Time) UUIDB32 { return UUIDB32(NewFromTime(t)) }
+
+func NewB32() UUIDB32 { return UUIDB32(New()) }
@@ -0,0 +1,81 @@
+package gouuidv6
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestB32(t *testing.T) {
+
+	u := NewB32()
+	s := u.String()
+	if len(s) != 26 || strings.ToUpper(s) != s {
+		t.Fatalf("Expected 26 upper case characters, got %q", s)
+	}
+
+	for _, in := range []string{s, strings.ToLower(s)} {
+		u2, err := ParseB32(in)
+		if err != nil || u2 != u {
+			t.Fatalf("Expected %v from %q, got %v (%v)", u, in, u2, err)
+		}
+	}
+	for _, bad := range []string{s[1:], s[:25] + "1", s + "A", ""} {
+		if _, err := ParseB32(bad); err == nil {
+			t.Fatalf("Expected error parsing %q", bad)
+		}
+	}
+
+	// all 0xFF bytes: the last character only has one bit of data
+	var max UUIDB32
+	for i := range max {
+		max[i] = 0xFF
+	}
+	if got := max.String(); got != "77777777777777777777777774" {
+		t.Fatalf("Expected all 0xFF bytes to encode as 7s ending in 4, got %q", got)
+	}
+
+	if u.Time() != UUID(u).Time() || u.IsNil() || len(u.Bytes()) != 16 {
+		t.Fatalf("Unexpected Time, IsNil or Bytes for %v", u)
+	}
+}
+
+func TestB32Marshal(t *testing.T) {
+
+	u := NewB32()
+
+	b, err := json.Marshal(u)
+	if err != nil || string(b) != `"`+u.String()+`"` {
+		t.Fatalf("Did not get expected JSON, instead got: %s (%v)", b, err)
+	}
+	var u2 UUIDB32
+	if err := json.Unmarshal(b, &u2); err != nil || u2 != u {
+		t.Fatalf("Expected %v back from JSON, got %v (%v)", u, u2, err)
+	}
+
+	buf, err := u.AppendText([]byte("id="))
+	if err != nil || string(buf) != "id="+u.String() {
+		t.Fatalf("Unexpected AppendText output %q (%v)", buf, err)
+	}
+	var u3 UUIDB32
+	if err := u3.UnmarshalText(buf[3:]); err != nil || u3 != u {
+		t.Fatalf("Expected %v from UnmarshalText, got %v (%v)", u, u3, err)
+	}
+	if n := testing.AllocsPerRun(100, func() { buf = u.EncodeText(buf[:0]); u3.DecodeText(buf) }); n > 0 {
+		t.Fatalf("Expected no allocations, got %v", n)
+	}
+
+	v, err := u.Value()
+	if err != nil || v != u.String() {
+		t.Fatalf("Expected Value %q, got %#v (%v)", u.String(), v, err)
+	}
+	for _, in := range []interface{}{v, []byte(strings.ToLower(u.String()))} {
+		var u4 UUIDB32
+		if err := u4.Scan(in); err != nil || u4 != u {
+			t.Fatalf("Expected Scan of %#v to give %v, got %v (%v)", in, u, u4, err)
+		}
+	}
+	if err := u3.Scan(int64(1)); err == nil {
+		t.Fatalf("Expected error scanning an int64")
+	}
+}
@@ -26,7 +26,9 @@ func (s UUIDB64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
 // B64String returns the UUID encoded with Base64UUIDEncoding.
 func (u UUIDB64) String() string {
-	return Base64UUIDEncoding.EncodeToString(u[:])
+	var b [22]byte
+	Base64UUIDEncoding.Encode(b[:], u[:])
+	return string(b[:])
 }
 
 // Parse base64 text representation
@@ -45,13 +47,42 @@ func ParseB64(us string) (UUIDB64, error) {
 
 }
 
+// EncodeText appends the base64 form of the UUID to dst and returns the
+// extended buffer.  It does not allocate if dst has room for 22 more bytes.
+func (u UUIDB64) EncodeText(dst []byte) []byte {
+	dst = append(dst, make([]byte, 22)...)
+	Base64UUIDEncoding.Encode(dst[len(dst)-22:], u[:])
+	return dst
+}
+
+// DecodeText sets the UUID from its 22 character base64 form in src without
+// allocating.
+func (u *UUIDB64) DecodeText(src []byte) error {
+	if len(src) != 22 {
+		return fmt.Errorf("invalid base64 UUID text %q", src)
+	}
+	var ret UUIDB64
+	if _, err := Base64UUIDEncoding.Decode(ret[:], src); err != nil {
+		return err
+	}
+	*u = ret
+	return nil
+}
+
 func (u UUIDB64) MarshalText() ([]byte, error)           { return []byte(u.String()), nil }
+func (u UUIDB64) AppendText(b []byte) ([]byte, error)    { return u.EncodeText(b), nil }
 func (u *UUIDB64) UnmarshalText(text []byte) (err error) { *u, err = ParseB64(string(text)); return }
 
 func (u UUIDB64) MarshalBinary() ([]byte, error)     { return u[:], nil }
 func (u *UUIDB64) UnmarshalBinary(data []byte) error { copy(u[:], data); return nil }
 
-func (u UUIDB64) MarshalJSON() ([]byte, error) { return []byte(`"` + u.String() + `"`), nil }
+func (u UUIDB64) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 24)
+	b[0], b[23] = '"', '"'
+	Base64UUIDEncoding.Encode(b[1:23], u[:])
+	return b, nil
+}
+
 func (u *UUIDB64) UnmarshalJSON(data []byte) error {
 	s := ""
 	err := json.Unmarshal(data, &s)
@@ -112,4 +143,4 @@ func (u UUIDB64) Time() time.Time { return UUID(u).Time() }
 
 func NewB64FromTime(t time.Time) UUIDB64 { return UUIDB64(NewFromTime(t)) }
 
-func NewB64() UUIDB64 { return NewB64FromTime(time.Now()) }
+func NewB64() UUIDB64 { return UUIDB64(New()) }
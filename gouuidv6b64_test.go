@@ -1,6 +1,7 @@
 package gouuidv6
 
 import (
+	"encoding/json"
 	"sort"
 	"testing"
 	"time"
@@ -57,3 +58,87 @@ func TestB64(t *testing.T) {
 	}
 
 }
+
+func TestB64JSON(t *testing.T) {
+
+	u := NewB64()
+
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"`+u.String()+`"` {
+		t.Fatalf("Did not get expected JSON, instead got: %s", b)
+	}
+
+	var u2 UUIDB64
+	if err := json.Unmarshal(b, &u2); err != nil {
+		t.Fatal(err)
+	}
+	if u2 != u {
+		t.Fatalf("Expected %v back from JSON, got %v", u, u2)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { u.MarshalJSON() }); n > 1 {
+		t.Fatalf("Expected at most 1 allocation for MarshalJSON, got %v", n)
+	}
+
+}
+
+func BenchmarkB64MarshalJSON(b *testing.B) {
+	u := NewB64()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		u.MarshalJSON()
+	}
+}
+
+func TestB64EncodeDecodeText(t *testing.T) {
+
+	u := NewB64()
+
+	buf := u.EncodeText(make([]byte, 0, 22))
+	if string(buf) != u.String() {
+		t.Fatalf("Expected %s from EncodeText, got %s", u, buf)
+	}
+
+	var u2 UUIDB64
+	if err := u2.DecodeText(buf); err != nil {
+		t.Fatal(err)
+	}
+	if u2 != u {
+		t.Fatalf("Expected %v from DecodeText, got %v", u, u2)
+	}
+
+	if err := u2.DecodeText(buf[1:]); err == nil {
+		t.Fatalf("Expected error decoding short text")
+	}
+
+	if n := testing.AllocsPerRun(100, func() { buf = u.EncodeText(buf[:0]); u2.DecodeText(buf) }); n > 0 {
+		t.Fatalf("Expected no allocations, got %v", n)
+	}
+
+}
+
+func TestB64StringAllocs(t *testing.T) {
+
+	u := NewB64()
+
+	b, err := u.AppendText([]byte("id="))
+	if err != nil || string(b) != "id="+u.String() {
+		t.Fatalf("Unexpected AppendText output %q (%v)", b, err)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _ = u.String() }); n > 1 {
+		t.Fatalf("Expected at most 1 allocation for String, got %v", n)
+	}
+
+}
+
+func BenchmarkB64String(b *testing.B) {
+	u := NewB64()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = u.String()
+	}
+}
@@ -0,0 +1,119 @@
+package gouuidv6
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const maxBatchWorkers = 256
+
+// NewBatchParallel returns n new UUIDs in strictly increasing order, generated
+// by workers goroutines at once, for seeding very large tables where a single
+// goroutine calling New is the bottleneck.  The workers share a random node
+// and each has its own partition of the clock sequence (see
+// WithClockSeqPartition), so one worker's UUIDs can never collide with
+// another's.  If workers is less than 1 GOMAXPROCS is used; it is limited to
+// 256, leaving each worker 64 clock sequence values per 100ns tick before its
+// Generator has to move the timestamp on.
+func NewBatchParallel(n, workers int) []UUID {
+	return newBatchParallel(n, workers)
+}
+
+// NewBatchParallel with opts added to each worker's Generator.
+func newBatchParallel(n, workers int, opts ...GeneratorOption) []UUID {
+
+	if n <= 0 {
+		return []UUID{}
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > maxBatchWorkers {
+		workers = maxBatchWorkers
+	}
+	if n < workers {
+		workers = 1
+	}
+
+	node, _ := defaultGenerator.randomNode()
+
+	// each worker fills and sorts its own part of buf
+	buf := make([]UUID, n)
+	parts := make([][]UUID, workers)
+	var wg sync.WaitGroup
+	for i := range parts {
+		parts[i] = buf[i*n/workers : (i+1)*n/workers]
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g := NewGenerator(append([]GeneratorOption{WithNode(node), WithClockSeqPartition(i, workers)}, opts...)...).Unsafe()
+			part := parts[i]
+			for j := range part {
+				part[j] = g.New()
+			}
+			// the clock moving backward can leave a part out of order
+			if !sort.SliceIsSorted(part, func(a, b int) bool { return less(part[a], part[b]) }) {
+				sort.Slice(part, func(a, b int) bool { return less(part[a], part[b]) })
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if workers == 1 {
+		return buf
+	}
+
+	// merge the parts, in O(n log workers)
+	iters := make([]func() (UUID, bool), workers)
+	for i, part := range parts {
+		iters[i] = SliceIter(part)
+	}
+	next := MergeSorted(iters...)
+	ret := make([]UUID, n)
+	for i := range ret {
+		ret[i], _ = next()
+	}
+
+	return ret
+}
+
+// Return true if a sorts before b as raw bytes.
+func less(a, b UUID) bool {
+	ahi, bhi := bigEnd.Uint64(a[:8]), bigEnd.Uint64(b[:8])
+	if ahi != bhi {
+		return ahi < bhi
+	}
+	return bigEnd.Uint64(a[8:]) < bigEnd.Uint64(b[8:])
+}
+
+// EncodeAllText returns the text form of each of uuids, each followed by sep,
+// in a single buffer.  See AppendAllText.
+func EncodeAllText(uuids []UUID, sep byte) []byte {
+	return AppendAllText(make([]byte, 0, len(uuids)*37), uuids, sep)
+}
+
+// AppendAllText appends the text form of each of uuids, each followed by sep,
+// to dst and returns the extended buffer.  It grows dst at most once and
+// encodes in a single loop, for exporters writing millions of IDs.
+func AppendAllText(dst []byte, uuids []UUID, sep byte) []byte {
+
+	n := len(dst)
+	dst = append(dst, make([]byte, len(uuids)*37)...)
+	b := dst[n:]
+
+	for _, u := range uuids {
+		for i, c := range u {
+			if i == 4 || i == 6 || i == 8 || i == 10 {
+				b[0] = '-'
+				b = b[1:]
+			}
+			b[0], b[1] = hexDigits[c>>4], hexDigits[c&0x0F]
+			b = b[2:]
+		}
+		b[0] = sep
+		b = b[1:]
+	}
+
+	return dst
+}
@@ -0,0 +1,97 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewBatchParallel(t *testing.T) {
+
+	for _, workers := range []int{0, 1, 3, 8} {
+
+		uuids := NewBatchParallel(10000, workers)
+
+		if len(uuids) != 10000 {
+			t.Fatalf("Expected 10000 UUIDs with %d workers, got %d", workers, len(uuids))
+		}
+		for i := 1; i < len(uuids); i++ {
+			if !less(uuids[i-1], uuids[i]) {
+				t.Fatalf("UUIDs not strictly increasing at %d with %d workers: %v, %v", i, workers, uuids[i-1], uuids[i])
+			}
+		}
+		if !uuids[0].IsValid() {
+			t.Fatalf("Expected valid UUIDs, got %v", uuids[0])
+		}
+
+	}
+
+	if uuids := NewBatchParallel(0, 4); len(uuids) != 0 {
+		t.Fatalf("Expected no UUIDs, got %d", len(uuids))
+	}
+
+}
+
+func TestNewBatchParallelCoarseClock(t *testing.T) {
+
+	// every worker sees the same time, so only the clock sequence partitions
+	// and the timestamp moving on keep the UUIDs apart
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	coarse := WithTimeFunc(func() time.Time { return tim })
+
+	for _, workers := range []int{maxBatchWorkers, 100000} {
+		uuids := newBatchParallel(50000, workers, coarse)
+		seen := make(map[UUID]bool, len(uuids))
+		for i, u := range uuids {
+			if seen[u] {
+				t.Fatalf("Duplicate UUID %v at %d with %d workers", u, i, workers)
+			}
+			seen[u] = true
+			if i > 0 && !less(uuids[i-1], u) {
+				t.Fatalf("UUIDs not strictly increasing at %d with %d workers: %v, %v", i, workers, uuids[i-1], u)
+			}
+		}
+	}
+
+}
+
+// One worker is the same as calling New n times, and more are only faster
+// with as many CPUs to run them on.
+func BenchmarkNewBatchParallel(b *testing.B) {
+	for _, workers := range []int{1, 4, 16, maxBatchWorkers} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewBatchParallel(1<<20, workers)
+			}
+		})
+	}
+}
+
+func TestAppendAllText(t *testing.T) {
+
+	uuids := []UUID{New(), New(), New()}
+
+	b := AppendAllText([]byte("ids:"), uuids, ',')
+	if want := "ids:" + uuids[0].String() + "," + uuids[1].String() + "," + uuids[2].String() + ","; string(b) != want {
+		t.Fatalf("Expected %q, got %q", want, b)
+	}
+
+	if b := EncodeAllText(nil, '\n'); len(b) != 0 {
+		t.Fatalf("Expected nothing for no UUIDs, got %q", b)
+	}
+
+	if n := testing.AllocsPerRun(10, func() { EncodeAllText(uuids, '\n') }); n > 1 {
+		t.Fatalf("Expected at most 1 allocation, got %v", n)
+	}
+
+}
+
+func BenchmarkEncodeAllText(b *testing.B) {
+	uuids := NewBatchParallel(1000, 1)
+	buf := make([]byte, 0, 1000*37)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = AppendAllText(buf[:0], uuids, '\n')
+	}
+}
@@ -0,0 +1,49 @@
+package gouuidv6
+
+import "strconv"
+
+// BindError is returned by BindParam.  Its Reason reads naturally after the
+// name of the parameter, so handlers can report "id must be ..." to clients
+// without exposing parser internals.
+type BindError struct {
+	Value  string // the parameter as given
+	Reason string // e.g. "is required" or "must be a UUID"
+}
+
+func (e *BindError) Error() string {
+	return "gouuidv6: parameter " + strconv.Quote(e.Value) + " " + e.Reason
+}
+
+// BindParam parses a path or query parameter containing a UUID in either the
+// standard hex form or the 22 character base64 form (see UUIDB64), and
+// requires it to be IsValid, so every handler accepts the same IDs in the
+// same way with one call.  Errors are always a *BindError.
+func BindParam(s string) (UUID, error) {
+
+	var u UUID
+	switch len(s) {
+	case 0:
+		return u, &BindError{Value: s, Reason: "is required"}
+	case 36:
+		if u.DecodeText([]byte(s)) != nil {
+			return UUID{}, &BindError{Value: s, Reason: "must be a UUID"}
+		}
+	case 22:
+		var b UUIDB64
+		if b.DecodeText([]byte(s)) != nil {
+			return UUID{}, &BindError{Value: s, Reason: "must be a UUID"}
+		}
+		u = UUID(b)
+	default:
+		return u, &BindError{Value: s, Reason: "must be a UUID"}
+	}
+
+	if !u.isV6() {
+		return UUID{}, &BindError{Value: s, Reason: "must be a version 6 UUID"}
+	}
+	if u.validate() != nil {
+		return UUID{}, &BindError{Value: s, Reason: "must be a UUID with a valid time"}
+	}
+
+	return u, nil
+}
@@ -0,0 +1,37 @@
+package gouuidv6
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBindParam(t *testing.T) {
+
+	u := New()
+
+	for _, s := range []string{u.String(), UUIDB64(u).String()} {
+		got, err := BindParam(s)
+		if err != nil {
+			t.Fatalf("Expected %q to bind, got %v", s, err)
+		}
+		if got != u {
+			t.Fatalf("Expected %v from %q, got %v", u, s, got)
+		}
+	}
+
+	for s, reason := range map[string]string{
+		"":                                     "is required",
+		"nope":                                 "must be a UUID",
+		"1ef9c3a2-zzzz-6000-8000-000000000000": "must be a UUID",
+		"7d444840-9dc0-11d1-b245-5ffdce74fad2": "must be a version 6 UUID",
+		NewFromTime(time.Now().Add(2 * maxFutureTime)).String(): "must be a UUID with a valid time",
+	} {
+		_, err := BindParam(s)
+		var be *BindError
+		if !errors.As(err, &be) || be.Reason != reason || be.Value != s {
+			t.Fatalf("Expected %q to fail with %q, got %v", s, reason, err)
+		}
+	}
+
+}
@@ -0,0 +1,20 @@
+package gouuidv6
+
+import "crypto/sha1"
+
+// Child returns the n'th child of the UUID, for labelling the sub-tasks of a
+// fan-out job with IDs related to the job's.  Children are deterministic,
+// so the same parent and n always give the same child, and keep the parent's
+// time and clock sequence, so they sort with it.  The node is replaced with
+// one derived from a hash of the parent with n in its middle bytes, so all
+// children of a parent sort together in order of n, and the multicast bit
+// set, so they cannot collide with UUIDs from a real MAC address.
+// Children of children are derived the same way.
+func (u UUID) Child(n uint16) UUID {
+	h := sha1.Sum(u[:])
+	ret := u
+	ret[10] = h[0] | 0x01
+	bigEnd.PutUint16(ret[11:13], n)
+	copy(ret[13:], h[1:4])
+	return ret
+}
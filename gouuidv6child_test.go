@@ -0,0 +1,33 @@
+package gouuidv6
+
+import "testing"
+
+func TestChild(t *testing.T) {
+
+	p := New()
+
+	if p.Child(3) != p.Child(3) {
+		t.Fatalf("Expected the same child for the same n")
+	}
+
+	prev := p.Child(0)
+	for n := 1; n < 1000; n++ {
+		c := p.Child(uint16(n))
+		if !less(prev, c) {
+			t.Fatalf("Expected children in order of n, got %v then %v", prev, c)
+		}
+		if !c.IsValid() || c.Time() != p.Time() || c.ClockSeq() != p.ClockSeq() || !c.IsRandomNode() {
+			t.Fatalf("Expected child %v to keep the time and clock sequence of %v", c, p)
+		}
+		prev = c
+	}
+
+	if New().Child(0) == p.Child(0) {
+		t.Fatalf("Expected different parents to have different children")
+	}
+
+	if gc := p.Child(1).Child(1); gc == p.Child(1) || gc.Time() != p.Time() {
+		t.Fatalf("Expected grandchild to differ from child but keep the time, got %v", gc)
+	}
+
+}
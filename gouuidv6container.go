@@ -0,0 +1,82 @@
+package gouuidv6
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"io"
+	"os"
+)
+
+// Environment variables read by ContainerNode, as typically set from pod
+// metadata with the Kubernetes downward API.
+const (
+	EnvPodUID  = "POD_UID"
+	EnvPodName = "POD_NAME"
+)
+
+// NodeProvider returns a 48-bit node, for use with WithNode.
+type NodeProvider func() (uint64, error)
+
+// files searched by ContainerNode for a container ID
+var containerIDFiles = []string{"/proc/self/cgroup", "/proc/self/mountinfo"}
+
+// ContainerNode is a NodeProvider for processes running in containers, where
+// MAC addresses are often identical between instances or meaningless.  The
+// node is derived from a hash of the pod UID or pod name (see EnvPodUID and
+// EnvPodName), or failing that the container ID found in /proc, with the
+// multicast bit set since it is not a real MAC address.  If none of these are
+// available it returns a random node.
+func ContainerNode() (uint64, error) {
+
+	for _, name := range []string{EnvPodUID, EnvPodName} {
+		if v := os.Getenv(name); v != "" {
+			return hashNode(v), nil
+		}
+	}
+
+	for _, fn := range containerIDFiles {
+		f, err := os.Open(fn)
+		if err != nil {
+			continue
+		}
+		id := containerID(f)
+		f.Close()
+		if id != "" {
+			return hashNode(id), nil
+		}
+	}
+
+	return randomNode()
+}
+
+// Return a node from the first 6 bytes of the SHA-1 hash of s, with the
+// multicast bit set.
+func hashNode(s string) uint64 {
+	h := sha1.Sum([]byte(s))
+	return macNode(h[:6]) | 0x0000010000000000
+}
+
+// Return the first 64 character hex string (the form Docker, containerd and
+// CRI-O use for container IDs) found in r, or "" if there is none.
+func containerID(r io.Reader) string {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		n := 0
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			if ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') {
+				n++
+				continue
+			}
+			if n == 64 {
+				return line[i-64 : i]
+			}
+			n = 0
+		}
+		if n == 64 {
+			return line[len(line)-64:]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package gouuidv6
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestContainerNode(t *testing.T) {
+
+	defer os.Unsetenv(EnvPodUID)
+
+	os.Setenv(EnvPodUID, "8b3c4f5e-1d2a-4b6c-9e8f-0a1b2c3d4e5f")
+	n1, err := ContainerNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n2, _ := ContainerNode()
+	if n1 != n2 {
+		t.Fatalf("Expected the same node for the same pod, got %x and %x", n1, n2)
+	}
+	if n1&0x0000010000000000 == 0 {
+		t.Fatalf("Expected multicast bit set in node %x", n1)
+	}
+
+	os.Setenv(EnvPodUID, "another-pod")
+	if n3, _ := ContainerNode(); n3 == n1 {
+		t.Fatalf("Expected a different node for a different pod")
+	}
+
+}
+
+func TestContainerID(t *testing.T) {
+
+	id := "4e6f1c2d7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d"
+
+	for _, in := range []string{
+		"0::/system.slice/docker-" + id + ".scope\n",
+		"12:memory:/docker/" + id + "\n",
+		"1 2 0:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw\n",
+	} {
+		if got := containerID(strings.NewReader(in)); got != id {
+			t.Fatalf("Expected %s from %q, got %q", id, in, got)
+		}
+	}
+
+	if got := containerID(strings.NewReader("0::/user.slice\n")); got != "" {
+		t.Fatalf("Expected no container ID, got %q", got)
+	}
+
+}
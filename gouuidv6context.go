@@ -0,0 +1,18 @@
+package gouuidv6
+
+import "context"
+
+// key for the UUID stored in a context
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying u, such as the ID of the request
+// being handled.
+func NewContext(ctx context.Context, u UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns the UUID carried by ctx, if it has one.
+func FromContext(ctx context.Context) (UUID, bool) {
+	u, ok := ctx.Value(contextKey{}).(UUID)
+	return u, ok
+}
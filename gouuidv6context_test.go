@@ -0,0 +1,19 @@
+package gouuidv6
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatalf("Expected no UUID in an empty context")
+	}
+
+	u := New()
+	if got, ok := FromContext(NewContext(context.Background(), u)); !ok || got != u {
+		t.Fatalf("Expected %v from context, got %v (%v)", u, got, ok)
+	}
+
+}
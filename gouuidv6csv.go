@@ -0,0 +1,113 @@
+package gouuidv6
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadCSVColumn reads CSV from r and returns the UUIDs in column col
+// (counting from 0) of every row, for importing IDs in bulk.  UUIDs may be in
+// the standard hex form or the base64 form of UUIDB64, and are not required
+// to be IsValid since migrated data often holds other versions.  A UTF-8 byte
+// order mark at the start is skipped, as is a first row whose column is not
+// a UUID, taken to be a header.  A row that is too short or holds something
+// else in the column is an error naming its line.
+func ReadCSVColumn(r io.Reader, col int) ([]UUID, error) {
+
+	if col < 0 {
+		return nil, fmt.Errorf("gouuidv6: invalid column %d", col)
+	}
+
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && string(b) == "\xef\xbb\xbf" {
+		br.Discard(3)
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	var ret []UUID
+	for first := true; ; first = false {
+
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return ret, nil
+		}
+		if err != nil {
+			return ret, fmt.Errorf("gouuidv6: %v", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		if col >= len(rec) {
+			return ret, fmt.Errorf("gouuidv6: line %d: no column %d in row of %d", line, col, len(rec))
+		}
+		line, _ = cr.FieldPos(col)
+
+		u, ok := parseCSVField(strings.TrimSpace(rec[col]))
+		if !ok {
+			if first {
+				continue
+			}
+			return ret, fmt.Errorf("gouuidv6: line %d: invalid UUID %q", line, rec[col])
+		}
+		ret = append(ret, u)
+	}
+}
+
+// Return the UUID in s in hex or base64 form.
+func parseCSVField(s string) (UUID, bool) {
+	var u UUID
+	switch len(s) {
+	case 36:
+		return u, u.DecodeText([]byte(s)) == nil
+	case 22:
+		var b UUIDB64
+		err := b.DecodeText([]byte(s))
+		return UUID(b), err == nil
+	}
+	return u, false
+}
+
+// CSVFormat is the text form CSVWriter writes UUIDs in.
+type CSVFormat int
+
+const (
+	CSVHex    CSVFormat = iota // standard hex form, see UUID.String
+	CSVBase64                  // base64 form, see UUIDB64.String
+)
+
+// CSVWriter writes rows of CSV starting with a UUID, for exporting IDs in
+// bulk.  Other fields are quoted as needed.  Call Flush when done.
+type CSVWriter struct {
+	w      *csv.Writer
+	format CSVFormat
+	rec    []string
+	buf    []byte
+}
+
+// NewCSVWriter returns a CSVWriter writing to w with UUIDs in format.
+func NewCSVWriter(w io.Writer, format CSVFormat) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), format: format}
+}
+
+// Write writes a row with u in the first column followed by fields.
+func (cw *CSVWriter) Write(u UUID, fields ...string) error {
+	if cw.format == CSVBase64 {
+		cw.buf = UUIDB64(u).EncodeText(cw.buf[:0])
+	} else {
+		cw.buf = u.EncodeText(cw.buf[:0])
+	}
+	cw.rec = append(append(cw.rec[:0], string(cw.buf)), fields...)
+	return cw.w.Write(cw.rec)
+}
+
+// Flush writes any buffered rows to the underlying io.Writer and returns any
+// error from this or an earlier Write.
+func (cw *CSVWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
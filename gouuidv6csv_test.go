@@ -0,0 +1,61 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+
+	ids := []UUID{New(), New(), New()}
+
+	for _, format := range []CSVFormat{CSVHex, CSVBase64} {
+
+		var buf bytes.Buffer
+		buf.WriteString("\xef\xbb\xbfname,id\n")
+		cw := NewCSVWriter(&buf, format)
+		for i, u := range ids {
+			if err := cw.Write(u, "row, "+string(rune('a'+i))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := cw.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ReadCSVColumn(&buf, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(ids) {
+			t.Fatalf("Expected %d UUIDs, got %d", len(ids), len(got))
+		}
+		for i := range ids {
+			if got[i] != ids[i] {
+				t.Fatalf("Expected %v at %d, got %v", ids[i], i, got[i])
+			}
+		}
+
+	}
+
+	in := "id,n\n" + ids[0].String() + ",1\n\"" + ids[1].String() + "\",2\nnope,3\n"
+	if _, err := ReadCSVColumn(strings.NewReader(in), 0); err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("Expected error on line 4, got %v", err)
+	}
+
+	in = "n,id\n1," + ids[0].String() + "\nshort\n"
+	if _, err := ReadCSVColumn(strings.NewReader(in), 1); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("Expected error on line 3, got %v", err)
+	}
+
+	in = ids[0].String() + "\n" + ids[1].String() + "\n"
+	if _, err := ReadCSVColumn(strings.NewReader(in), 2); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("Expected error on line 1 for missing column, got %v", err)
+	}
+
+	if _, err := ReadCSVColumn(strings.NewReader(in), -1); err == nil {
+		t.Fatalf("Expected error for a negative column")
+	}
+
+}
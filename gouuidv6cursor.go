@@ -0,0 +1,87 @@
+package gouuidv6
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"math"
+)
+
+// Cursor is a position in a list of records keyed by UUID, for APIs that
+// paginate by time-ordered IDs ("the next Limit records after ID").  It is
+// handed to clients as an opaque token by Encode and read back by
+// DecodeCursor, which rejects tokens that were not created with the same
+// key, so clients cannot forge or alter them.
+type Cursor struct {
+	ID       UUID // last record of the previous page
+	Backward bool // page towards older records
+	Limit    int  // page size, 0 to 0xFFFFFFFF
+}
+
+// version of the cursor token layout, the first byte of every token
+const cursorVersion = 1
+
+// token layout: version, UUID, flags, limit (big endian), truncated HMAC
+const (
+	cursorDataLen = 1 + 16 + 1 + 4
+	cursorMACLen  = 16
+	cursorLen     = cursorDataLen + cursorMACLen
+)
+
+// Encode returns the cursor as a URL safe token signed with key using
+// HMAC-SHA-256.  The token is not encrypted: clients can decode the UUID
+// (and so its time) if they try; use UUID.Encrypt on ID first if that
+// matters.  It panics if Limit is out of range.
+func (c Cursor) Encode(key []byte) string {
+
+	if c.Limit < 0 || uint64(c.Limit) > math.MaxUint32 {
+		panic(fmt.Errorf("gouuidv6: cursor limit %d out of range", c.Limit))
+	}
+
+	var b [cursorLen]byte
+	b[0] = cursorVersion
+	copy(b[1:17], c.ID[:])
+	if c.Backward {
+		b[17] = 1
+	}
+	bigEnd.PutUint32(b[18:22], uint32(c.Limit))
+	copy(b[cursorDataLen:], cursorMAC(key, b[:cursorDataLen]))
+
+	return Base64UUIDEncoding.EncodeToString(b[:])
+}
+
+// DecodeCursor returns the cursor in token s created by Encode with key, or
+// an error if it is malformed or was not signed with key.
+func DecodeCursor(s string, key []byte) (Cursor, error) {
+
+	var b [cursorLen]byte
+	if Base64UUIDEncoding.EncodedLen(cursorLen) != len(s) {
+		return Cursor{}, fmt.Errorf("gouuidv6: invalid cursor")
+	}
+	if _, err := Base64UUIDEncoding.Decode(b[:], []byte(s)); err != nil {
+		return Cursor{}, fmt.Errorf("gouuidv6: invalid cursor")
+	}
+	if !hmac.Equal(b[cursorDataLen:], cursorMAC(key, b[:cursorDataLen])) {
+		return Cursor{}, fmt.Errorf("gouuidv6: invalid cursor signature")
+	}
+	if b[0] != cursorVersion {
+		return Cursor{}, fmt.Errorf("gouuidv6: unsupported cursor version %d", b[0])
+	}
+	if b[17] > 1 {
+		return Cursor{}, fmt.Errorf("gouuidv6: invalid cursor")
+	}
+
+	var c Cursor
+	copy(c.ID[:], b[1:17])
+	c.Backward = b[17] == 1
+	c.Limit = int(bigEnd.Uint32(b[18:22]))
+	return c, nil
+}
+
+// Return the truncated HMAC-SHA-256 of data with key.
+func cursorMAC(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	var sum [sha256.Size]byte
+	return h.Sum(sum[:0])[:cursorMACLen]
+}
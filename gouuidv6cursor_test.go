@@ -0,0 +1,46 @@
+package gouuidv6
+
+import "testing"
+
+func TestCursor(t *testing.T) {
+
+	key := []byte("cursor key")
+
+	for _, c := range []Cursor{
+		{ID: New(), Limit: 50},
+		{ID: New(), Backward: true, Limit: 1<<31 - 1},
+		{},
+	} {
+		s := c.Encode(key)
+		got, err := DecodeCursor(s, key)
+		if err != nil {
+			t.Fatalf("Expected %q to decode, got %v", s, err)
+		}
+		if got != c {
+			t.Fatalf("Expected %+v, got %+v", c, got)
+		}
+	}
+
+	s := Cursor{ID: New(), Limit: 10}.Encode(key)
+
+	if _, err := DecodeCursor(s, []byte("other key")); err == nil {
+		t.Fatalf("Expected error decoding with the wrong key")
+	}
+
+	b := []byte(s)
+	if b[5] == 'A' {
+		b[5] = 'B'
+	} else {
+		b[5] = 'A'
+	}
+	if _, err := DecodeCursor(string(b), key); err == nil {
+		t.Fatalf("Expected error decoding altered cursor %q", b)
+	}
+
+	for _, bad := range []string{"", "nope", s[1:], s + "A"} {
+		if _, err := DecodeCursor(bad, key); err == nil {
+			t.Fatalf("Expected error decoding %q", bad)
+		}
+	}
+
+}
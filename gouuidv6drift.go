@@ -0,0 +1,43 @@
+package gouuidv6
+
+import "time"
+
+// DriftReporter is told how far the local clock is estimated to be from the
+// true time, for instance by an NTP or chrony client.  A positive drift means
+// the local clock is ahead.  Generator implements it, so a time sync client
+// can be written against this interface without depending on Generator.
+type DriftReporter interface {
+	ReportDrift(drift time.Duration)
+}
+
+// WithDriftThreshold makes the Generator warn when the drift reported with
+// ReportDrift is more than max either way, meaning the times in its UUIDs may
+// be that far wrong: fn (if not nil) is called with the drift, the warning is
+// counted in the metrics and Healthz reports an error until a drift within
+// max is reported.
+func WithDriftThreshold(max time.Duration, fn func(drift time.Duration)) GeneratorOption {
+	return func(g *Generator) {
+		g.driftThreshold = max
+		g.onDrift = fn
+	}
+}
+
+// ReportDrift records the estimated drift of the Generator's clock from the
+// true time, shown in its metrics and checked against WithDriftThreshold.
+func (g *Generator) ReportDrift(drift time.Duration) {
+	g.counters.drift.Store(int64(drift))
+	if g.driftExceeded(drift) {
+		g.counters.driftWarnings.Add(1)
+		if g.onDrift != nil {
+			g.onDrift(drift)
+		}
+	}
+}
+
+// Report the estimated drift of the clock to the default Generator.
+func ReportDrift(drift time.Duration) { defaultGenerator.ReportDrift(drift) }
+
+// Return true if drift is beyond the Generator's threshold, if it has one.
+func (g *Generator) driftExceeded(drift time.Duration) bool {
+	return g.driftThreshold > 0 && (drift > g.driftThreshold || drift < -g.driftThreshold)
+}
@@ -0,0 +1,35 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportDrift(t *testing.T) {
+
+	var warned []time.Duration
+	g := NewGenerator(WithDriftThreshold(100*time.Millisecond, func(d time.Duration) { warned = append(warned, d) }))
+	var r DriftReporter = g
+
+	r.ReportDrift(50 * time.Millisecond)
+	if len(warned) != 0 || g.Healthz() != nil {
+		t.Fatalf("Expected no warning for drift within the threshold")
+	}
+
+	r.ReportDrift(-200 * time.Millisecond)
+	if len(warned) != 1 || warned[0] != -200*time.Millisecond {
+		t.Fatalf("Expected a warning for drift beyond the threshold, got %v", warned)
+	}
+	if err := g.Healthz(); err == nil {
+		t.Fatalf("Expected Healthz to report the drift")
+	}
+	if m := g.Metrics(); m.DriftWarnings != 1 || m.ClockDrift != int64(-200*time.Millisecond) {
+		t.Fatalf("Unexpected drift metrics %+v", m)
+	}
+
+	r.ReportDrift(0)
+	if err := g.Healthz(); err != nil {
+		t.Fatalf("Expected Healthz to pass after drift is corrected: %v", err)
+	}
+
+}
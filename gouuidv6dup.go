@@ -0,0 +1,117 @@
+package gouuidv6
+
+import (
+	"sync"
+	"time"
+)
+
+// DupDetector finds duplicate UUIDs in a stream (for instance UUIDs from many
+// Generators merged together), remembering each one for a window of time.
+// Expiry is based on the times embedded in the UUIDs rather than the local
+// clock: a UUID is forgotten once a UUID more than the window newer than it
+// has been added.  A DupDetector is safe for concurrent use.
+type DupDetector struct {
+	mu       sync.Mutex
+	window   int64 // in 100ns ticks
+	slotSize int64 // ticks per bucket
+	buckets  map[int64]map[UUID]struct{}
+	minSlot  int64 // lowest slot that may be in buckets
+	maxSlot  int64 // slot of the newest UUID seen
+	count    int
+	dups     uint64
+}
+
+// Return a new DupDetector that remembers UUIDs for window.
+func NewDupDetector(window time.Duration) *DupDetector {
+	w := int64(window / 100)
+	if w < 1 {
+		w = 1
+	}
+	// divide the window into 16 buckets, so expiry is accurate to 1/16th of it
+	slot := w / 16
+	if slot < 1 {
+		slot = 1
+	}
+	return &DupDetector{
+		window:   w,
+		slotSize: slot,
+		buckets:  make(map[int64]map[UUID]struct{}),
+	}
+}
+
+// Add records u and reports whether it is a duplicate of a UUID added within
+// the window.  UUIDs that are not version 6, or that are already older than
+// the window relative to the newest UUID added, are not tracked and are never
+// reported as duplicates.
+func (d *DupDetector) Add(u UUID) bool {
+
+	if !u.isV6() {
+		return false
+	}
+
+	slot := int64(u.timestamp()) / d.slotSize
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if slot > d.maxSlot {
+		d.maxSlot = slot
+		d.expire()
+	}
+	if slot < d.minSlot {
+		return false
+	}
+
+	b := d.buckets[slot]
+	if b == nil {
+		b = make(map[UUID]struct{})
+		d.buckets[slot] = b
+	}
+	if _, ok := b[u]; ok {
+		d.dups++
+		return true
+	}
+	b[u] = struct{}{}
+	d.count++
+	return false
+}
+
+// Return how many UUIDs are currently remembered.
+func (d *DupDetector) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.count
+}
+
+// Return how many duplicates have been found so far.
+func (d *DupDetector) Duplicates() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dups
+}
+
+// drop buckets that have fallen out of the window (called with mu held)
+func (d *DupDetector) expire() {
+
+	cutoff := d.maxSlot - d.window/d.slotSize
+	if cutoff <= d.minSlot {
+		return
+	}
+
+	if cutoff-d.minSlot > int64(len(d.buckets)) {
+		// big jump forward, quicker to look at what we have
+		for slot, b := range d.buckets {
+			if slot < cutoff {
+				d.count -= len(b)
+				delete(d.buckets, slot)
+			}
+		}
+	} else {
+		for slot := d.minSlot; slot < cutoff; slot++ {
+			d.count -= len(d.buckets[slot])
+			delete(d.buckets, slot)
+		}
+	}
+
+	d.minSlot = cutoff
+}
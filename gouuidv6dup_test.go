@@ -0,0 +1,47 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDupDetector(t *testing.T) {
+
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator()
+	d := NewDupDetector(time.Minute)
+
+	u1 := g.NewFromTime(start)
+	u2 := g.NewFromTime(start.Add(time.Second))
+
+	if d.Add(u1) || d.Add(u2) {
+		t.Fatalf("First sighting should not be a duplicate")
+	}
+	if !d.Add(u1) {
+		t.Fatalf("Second sighting of %v should be a duplicate", u1)
+	}
+	if d.Len() != 2 || d.Duplicates() != 1 {
+		t.Fatalf("Expected 2 remembered and 1 duplicate, got %d and %d", d.Len(), d.Duplicates())
+	}
+
+	// move well past the window, the earlier ones should be forgotten
+	u3 := g.NewFromTime(start.Add(2 * time.Minute))
+	d.Add(u3)
+	if d.Len() != 1 {
+		t.Fatalf("Expected old UUIDs to expire, still have %d", d.Len())
+	}
+	if d.Add(u1) {
+		t.Fatalf("UUID older than the window should not be reported")
+	}
+
+	// and a jump of years shouldn't take forever
+	d.Add(g.NewFromTime(start.AddDate(10, 0, 0)))
+	if d.Len() != 1 {
+		t.Fatalf("Expected 1 remembered after a big jump, got %d", d.Len())
+	}
+
+	if d.Add(UUID{}) || d.Add(UUID{}) {
+		t.Fatalf("Non version 6 UUIDs should not be tracked")
+	}
+
+}
@@ -0,0 +1,59 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"time"
+)
+
+// EntropyPolicy says what a Generator does when it cannot read random data
+// (see SetRandReader), for its starting clock sequence and node, random nodes
+// from WithAlwaysRandomizeNode or timestamps from WithPrecision.  Whatever the
+// policy, failures are counted in the Generator's metrics.
+type EntropyPolicy int
+
+const (
+	// Use random data from math/rand seeded with the time instead.  This is
+	// the default.
+	EntropyFallback EntropyPolicy = iota
+	// Panic.
+	EntropyPanic
+	// Have NewE return an error.  New and the other methods that cannot
+	// return an error use math/rand as with EntropyFallback.
+	EntropyError
+)
+
+// WithEntropyPolicy sets what the Generator does when it cannot read random
+// data.
+func WithEntropyPolicy(p EntropyPolicy) GeneratorOption {
+	return func(g *Generator) { g.entropyPolicy.Store(int32(p)) }
+}
+
+// Set what the default Generator does when it cannot read random data.
+func SetEntropyPolicy(p EntropyPolicy) { defaultGenerator.entropyPolicy.Store(int32(p)) }
+
+// Return a new UUID like New, or with the EntropyError policy an error if
+// random data could not be read for it, or when the Generator was created.
+func (g *Generator) NewE() (UUID, error) { return g.NewFromTimeE(g.now()) }
+
+// Return a new UUID with the time t like NewFromTime, or an error as NewE.
+func (g *Generator) NewFromTimeE(t time.Time) (UUID, error) {
+	if EntropyPolicy(g.entropyPolicy.Load()) != EntropyError {
+		return g.NewFromTime(t), nil
+	}
+	if g.seedErr != nil {
+		return UUID{}, fmt.Errorf("gouuidv6: generator created without random data: %v", g.seedErr)
+	}
+	var u UUID
+	if err := g.newFromTime(&u, t); err != nil {
+		return UUID{}, fmt.Errorf("gouuidv6: cannot read random data: %v", err)
+	}
+	return u, nil
+}
+
+// Count a failure to read random data, and panic if that is the policy.
+func (g *Generator) entropyFailure(err error) {
+	g.counters.entropyFailures.Add(1)
+	if EntropyPolicy(g.entropyPolicy.Load()) == EntropyPanic {
+		panic(fmt.Sprintf("gouuidv6: cannot read random data: %v", err))
+	}
+}
@@ -0,0 +1,50 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEntropyPolicy(t *testing.T) {
+
+	defer SetRandReader(nil)
+	SetRandReader(bytes.NewReader(nil))
+
+	// fallback still gives random values rather than zeros
+	g := NewGenerator()
+	u1, u2 := g.New(), NewGenerator().New()
+	if u1.Node().String() == "01:00:00:00:00:00" || u1.Node().String() == u2.Node().String() {
+		t.Fatalf("Expected fallback random nodes, got %v and %v", u1.Node(), u2.Node())
+	}
+	if u, err := g.NewE(); err != nil || u.IsNil() {
+		t.Fatalf("Expected no error from NewE with the fallback policy, got %v", err)
+	}
+
+	g = NewGenerator(WithEntropyPolicy(EntropyError))
+	if _, err := g.NewE(); err == nil {
+		t.Fatalf("Expected error from NewE for a generator created without random data")
+	}
+	if u := g.New(); u.IsNil() {
+		t.Fatalf("Expected New to still work with the error policy")
+	}
+
+	SetRandReader(nil)
+	g = NewGenerator(WithEntropyPolicy(EntropyError), WithPrecision(1000))
+	if _, err := g.NewE(); err != nil {
+		t.Fatal(err)
+	}
+	SetRandReader(bytes.NewReader(nil))
+	if _, err := g.NewE(); err == nil {
+		t.Fatalf("Expected error from NewE when random data for the timestamp cannot be read")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Expected panic with the panic policy")
+			}
+		}()
+		NewGenerator(WithEntropyPolicy(EntropyPanic))
+	}()
+
+}
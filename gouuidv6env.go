@@ -0,0 +1,90 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// Environment variables read by ConfigureFromEnv.
+const (
+	EnvNode          = "GOUUIDV6_NODE"           // node as a number (e.g. 0xdeadbeef) or MAC address (e.g. 02:42:ac:11:00:02)
+	EnvRandomizeNode = "GOUUIDV6_RANDOMIZE_NODE" // "true" to use a random node, as with RandomizeNode
+	EnvInterface     = "GOUUIDV6_INTERFACE"      // name of the network interface whose MAC address is used as the node
+)
+
+// EnvMACNode, if "true" when the package is initialized, makes the default
+// Generator use the MAC address as its node (as with UseMACNode) instead of
+// a random node, for compatibility with earlier versions.  Unlike the
+// variables above it is read automatically.
+const EnvMACNode = "GOUUIDV6_MAC_NODE"
+
+// ConfigureFromEnv sets the node of the default Generator from the
+// environment variables above, so deployments can control node identity
+// without code changes.  At most one of them may be set; if none are the
+// default Generator is left as it is.  It is never called automatically.
+func ConfigureFromEnv() error {
+
+	var set []string
+	for _, name := range []string{EnvNode, EnvRandomizeNode, EnvInterface} {
+		if os.Getenv(name) != "" {
+			set = append(set, name)
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	if len(set) > 1 {
+		return fmt.Errorf("gouuidv6: only one of %v may be set", set)
+	}
+
+	v := os.Getenv(set[0])
+	switch set[0] {
+
+	case EnvNode:
+		node, err := parseNode(v)
+		if err != nil {
+			return fmt.Errorf("gouuidv6: invalid %s: %v", EnvNode, err)
+		}
+		setDefaultNode(node)
+
+	case EnvRandomizeNode:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("gouuidv6: invalid %s: %v", EnvRandomizeNode, err)
+		}
+		if b {
+			RandomizeNode()
+		}
+
+	case EnvInterface:
+		node, err := NodeFromInterface(v)
+		if err != nil {
+			return fmt.Errorf("gouuidv6: invalid %s: %v", EnvInterface, err)
+		}
+		setDefaultNode(node)
+
+	}
+
+	return nil
+}
+
+// parse a node given either as a number or in MAC address form
+func parseNode(s string) (uint64, error) {
+	if mac, err := net.ParseMAC(s); err == nil && len(mac) == 6 {
+		return macNode(mac), nil
+	}
+	node, err := strconv.ParseUint(s, 0, 48)
+	if err != nil {
+		return 0, err
+	}
+	if node == 0 {
+		return 0, fmt.Errorf("node must not be zero")
+	}
+	return node, nil
+}
+
+func setDefaultNode(node uint64) {
+	defaultGenerator.node.Store(node)
+}
@@ -0,0 +1,48 @@
+package gouuidv6
+
+import (
+	"os"
+	"testing"
+)
+
+func TestConfigureFromEnv(t *testing.T) {
+
+	defer setDefaultNode(defaultGenerator.node.Load())
+	defer os.Unsetenv(EnvNode)
+	defer os.Unsetenv(EnvRandomizeNode)
+
+	os.Setenv(EnvNode, "0xdeadbeef")
+	if err := ConfigureFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if n := New().Node().String(); n != "00:00:de:ad:be:ef" {
+		t.Fatalf("Expected node from %s, got %s", EnvNode, n)
+	}
+
+	os.Setenv(EnvNode, "02:42:ac:11:00:02")
+	if err := ConfigureFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if n := New().Node().String(); n != "02:42:ac:11:00:02" {
+		t.Fatalf("Expected MAC node from %s, got %s", EnvNode, n)
+	}
+
+	os.Setenv(EnvNode, "nope")
+	if err := ConfigureFromEnv(); err == nil {
+		t.Fatalf("Expected error for invalid %s", EnvNode)
+	}
+
+	os.Setenv(EnvRandomizeNode, "true")
+	if err := ConfigureFromEnv(); err == nil {
+		t.Fatalf("Expected error with more than one variable set")
+	}
+
+	os.Unsetenv(EnvNode)
+	if err := ConfigureFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if n := New().Node(); n[0]&0x01 == 0 {
+		t.Fatalf("Expected random node with multicast bit, got %v", n)
+	}
+
+}
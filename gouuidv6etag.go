@@ -0,0 +1,54 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ETag returns the UUID as an HTTP entity tag, e.g.
+// "1ec0450e-5a64-6ca0-a4f1-5b0fa3b1fb93" with the quotes, or prefixed with W/
+// if weak, for resources versioned by the UUID of their latest change.
+func (u UUID) ETag(weak bool) string {
+	b := make([]byte, 0, 40)
+	if weak {
+		b = append(b, 'W', '/')
+	}
+	b = append(b, '"')
+	b = u.EncodeText(b)
+	b = append(b, '"')
+	return string(b)
+}
+
+// ParseETag parses an entity tag created by UUID.ETag, returning the UUID and
+// whether the tag is weak.
+func ParseETag(s string) (u UUID, weak bool, err error) {
+	t := s
+	if strings.HasPrefix(t, "W/") {
+		weak, t = true, t[2:]
+	}
+	if len(t) != 38 || t[0] != '"' || t[37] != '"' {
+		return UUID{}, false, fmt.Errorf("gouuidv6: invalid ETag %q", s)
+	}
+	if err := u.DecodeText([]byte(t[1:37])); err != nil {
+		return UUID{}, false, fmt.Errorf("gouuidv6: invalid ETag %q", s)
+	}
+	return u, weak, nil
+}
+
+// MatchETag reports whether header, the value of an If-None-Match or
+// If-Match request header, matches the UUID: if it is "*" or lists the
+// UUID's ETag, weak or strong.  This is the weak comparison of RFC 9110,
+// which is what If-None-Match uses; for If-Match with strong ETags compare
+// the result of ParseETag instead.  Tags that are not UUIDs are ignored.
+func (u UUID) MatchETag(header string) bool {
+	for _, s := range strings.Split(header, ",") {
+		s = strings.TrimSpace(s)
+		if s == "*" {
+			return true
+		}
+		if v, _, err := ParseETag(s); err == nil && v == u {
+			return true
+		}
+	}
+	return false
+}
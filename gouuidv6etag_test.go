@@ -0,0 +1,42 @@
+package gouuidv6
+
+import "testing"
+
+func TestETag(t *testing.T) {
+
+	u := New()
+
+	if s := u.ETag(false); s != `"`+u.String()+`"` {
+		t.Fatalf("Unexpected strong ETag %s", s)
+	}
+	if s := u.ETag(true); s != `W/"`+u.String()+`"` {
+		t.Fatalf("Unexpected weak ETag %s", s)
+	}
+
+	for _, weak := range []bool{false, true} {
+		got, gotWeak, err := ParseETag(u.ETag(weak))
+		if err != nil || got != u || gotWeak != weak {
+			t.Fatalf("Expected %v (weak=%v), got %v (weak=%v, err=%v)", u, weak, got, gotWeak, err)
+		}
+	}
+
+	for _, s := range []string{"", `""`, u.String(), `w/"` + u.String() + `"`, `"` + u.String(), `"xyz"`} {
+		if _, _, err := ParseETag(s); err == nil {
+			t.Fatalf("Expected error parsing %q", s)
+		}
+	}
+
+	other := New()
+	for header, want := range map[string]bool{
+		u.ETag(false):                           true,
+		other.ETag(false) + ", " + u.ETag(true): true,
+		"*":                                     true,
+		other.ETag(false) + `, "v1"`:            false,
+		"":                                      false,
+	} {
+		if got := u.MatchETag(header); got != want {
+			t.Fatalf("Expected MatchETag(%q) = %v, got %v", header, want, got)
+		}
+	}
+
+}
@@ -0,0 +1,244 @@
+package gouuidv6
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ExternalSorter sorts streams of UUIDs far larger than memory: it sorts
+// chunks of the input in memory, writes each to a temporary file and then
+// merges them with MergeSorted, at most MaxFanIn files at a time, in as many
+// passes as it takes.  Input that fits in one chunk is sorted without
+// temporary files.  The zero value is ready to use.
+type ExternalSorter struct {
+	// Read and write raw 16-byte UUIDs rather than lines of text.  Text
+	// input may be in the standard hex or base64 form, one per line, and is
+	// written in the standard hex form.
+	Binary bool
+	// Sort in descending order.
+	Reverse bool
+	// Most UUIDs held in memory at once, 1<<20 (16MB) if zero.
+	ChunkSize int
+	// Most temporary files open and merged at once, 64 if less than 2.
+	MaxFanIn int
+	// Directory for temporary files, os.TempDir() if empty.
+	TempDir string
+}
+
+// Sort reads UUIDs from src and writes them to dst in order.
+func (s *ExternalSorter) Sort(dst io.Writer, src io.Reader) error {
+
+	size := s.ChunkSize
+	if size <= 0 {
+		size = 1 << 20
+	}
+
+	// in reverse the UUIDs are complemented, sorted and merged ascending,
+	// and complemented back when written
+	flip := func(u UUID) UUID {
+		if s.Reverse {
+			bigEnd.PutUint64(u[:8], ^bigEnd.Uint64(u[:8]))
+			bigEnd.PutUint64(u[8:], ^bigEnd.Uint64(u[8:]))
+		}
+		return u
+	}
+
+	next := s.reader(src)
+	chunk := make([]UUID, 0, size)
+	var names []string // sorted temporary files not merged yet
+	defer func() {
+		for _, name := range names {
+			os.Remove(name)
+		}
+	}()
+
+	for done := false; !done; {
+
+		chunk = chunk[:0]
+		for len(chunk) < size {
+			u, ok, err := next()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				done = true
+				break
+			}
+			chunk = append(chunk, flip(u))
+		}
+
+		sort.Slice(chunk, func(i, j int) bool { return less(chunk[i], chunk[j]) })
+		if done && names == nil {
+			// everything fit in one chunk
+			return s.write(dst, SliceIter(chunk), flip)
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		name, err := writeTemp(s.TempDir, SliceIter(chunk))
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+
+	fanIn := s.MaxFanIn
+	if fanIn < 2 {
+		fanIn = 64
+	}
+
+	// merge groups of files into longer ones until there are few enough to
+	// merge into dst
+	for len(names) > fanIn {
+		var merged []string
+		for len(names) > 0 {
+			n := fanIn
+			if n > len(names) {
+				n = len(names)
+			}
+			var name string
+			err := mergeTemp(names[:n], func(next func() (UUID, bool)) (err error) {
+				name, err = writeTemp(s.TempDir, next)
+				return err
+			})
+			if name != "" {
+				merged = append(merged, name)
+			}
+			if err != nil {
+				names = append(names, merged...)
+				return err
+			}
+			for _, done := range names[:n] {
+				os.Remove(done)
+			}
+			names = names[n:]
+		}
+		names = merged
+	}
+
+	return mergeTemp(names, func(next func() (UUID, bool)) error { return s.write(dst, next, flip) })
+}
+
+// Write the UUIDs from next to a new temporary file in dir as raw bytes,
+// returning its name.  The file is closed, so only the files being merged
+// are open at once.
+func writeTemp(dir string, next func() (UUID, bool)) (string, error) {
+
+	f, err := os.CreateTemp(dir, "gouuidv6-sort-")
+	if err != nil {
+		return "", fmt.Errorf("gouuidv6: %v", err)
+	}
+
+	w := bufio.NewWriter(f)
+	for u, ok := next(); ok; u, ok = next() {
+		w.Write(u[:])
+	}
+	err = w.Flush()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("gouuidv6: %v", err)
+	}
+	return f.Name(), nil
+}
+
+// Open the sorted temporary files names and pass their merged UUIDs to
+// write, returning its error or else any error reading the files.
+func mergeTemp(names []string, write func(next func() (UUID, bool)) error) error {
+
+	iters := make([]func() (UUID, bool), len(names))
+	var readErr error
+	for i, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return fmt.Errorf("gouuidv6: %v", err)
+		}
+		defer f.Close()
+		r := bufio.NewReader(f)
+		iters[i] = func() (UUID, bool) {
+			var u UUID
+			if _, err := io.ReadFull(r, u[:]); err != nil {
+				if err != io.EOF && readErr == nil {
+					readErr = fmt.Errorf("gouuidv6: %v", err)
+				}
+				return u, false
+			}
+			return u, true
+		}
+	}
+
+	if err := write(MergeSorted(iters...)); err != nil {
+		return err
+	}
+	return readErr
+}
+
+// Return a function reading the next UUID from src, false at the end.
+func (s *ExternalSorter) reader(src io.Reader) func() (UUID, bool, error) {
+
+	br := bufio.NewReader(src)
+
+	if s.Binary {
+		return func() (UUID, bool, error) {
+			var u UUID
+			n, err := io.ReadFull(br, u[:])
+			switch {
+			case err == io.EOF:
+				return u, false, nil
+			case err == io.ErrUnexpectedEOF:
+				return u, false, fmt.Errorf("gouuidv6: input ends with a partial UUID of %d bytes", n)
+			case err != nil:
+				return u, false, fmt.Errorf("gouuidv6: %v", err)
+			}
+			return u, true, nil
+		}
+	}
+
+	sc := bufio.NewScanner(br)
+	line := 0
+	return func() (UUID, bool, error) {
+		for sc.Scan() {
+			line++
+			t := strings.TrimSpace(sc.Text())
+			if t == "" {
+				continue
+			}
+			u, ok := parseCSVField(t)
+			if !ok {
+				return u, false, fmt.Errorf("gouuidv6: line %d: invalid UUID %q", line, t)
+			}
+			return u, true, nil
+		}
+		if err := sc.Err(); err != nil {
+			return UUID{}, false, fmt.Errorf("gouuidv6: %v", err)
+		}
+		return UUID{}, false, nil
+	}
+}
+
+// Write the UUIDs from next to dst, each passed through flip.
+func (s *ExternalSorter) write(dst io.Writer, next func() (UUID, bool), flip func(UUID) UUID) error {
+	w := bufio.NewWriter(dst)
+	var buf [37]byte
+	for u, ok := next(); ok; u, ok = next() {
+		u = flip(u)
+		if s.Binary {
+			w.Write(u[:])
+			continue
+		}
+		encodeHex(buf[:36], u)
+		buf[36] = '\n'
+		w.Write(buf[:])
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("gouuidv6: %v", err)
+	}
+	return nil
+}
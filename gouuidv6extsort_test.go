@@ -0,0 +1,78 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestExternalSorter(t *testing.T) {
+
+	want := NewBatchParallel(1000, 1)
+	shuffled := append([]UUID(nil), want...)
+	rand.New(rand.NewSource(1)).Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	dir := t.TempDir()
+
+	for _, c := range []struct{ chunk, fanIn int }{{0, 0}, {1, 0}, {64, 0}, {1000, 0}, {1, 4}, {64, 4}} {
+
+		chunk := c.chunk
+
+		// binary
+		var in, out bytes.Buffer
+		for _, u := range shuffled {
+			in.Write(u[:])
+		}
+		s := &ExternalSorter{Binary: true, ChunkSize: chunk, MaxFanIn: c.fanIn, TempDir: dir}
+		if err := s.Sort(&out, &in); err != nil {
+			t.Fatal(err)
+		}
+		for i, u := range want {
+			if !bytes.Equal(out.Next(16), u[:]) {
+				t.Fatalf("Chunk size %d: wrong UUID at %d", chunk, i)
+			}
+		}
+
+		// text, with base64 and blank lines, in reverse
+		in.Reset()
+		for i, u := range shuffled {
+			if i%2 == 0 {
+				in.WriteString(UUIDB64(u).String() + "\n\n")
+			} else {
+				in.WriteString(" " + u.String() + "\n")
+			}
+		}
+		s = &ExternalSorter{Reverse: true, ChunkSize: chunk, MaxFanIn: c.fanIn, TempDir: dir}
+		if err := s.Sort(&out, &in); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		if len(lines) != len(want) {
+			t.Fatalf("Chunk size %d: expected %d lines, got %d", chunk, len(want), len(lines))
+		}
+		if !sort.SliceIsSorted(lines, func(i, j int) bool { return lines[i] > lines[j] }) || lines[0] != want[len(want)-1].String() {
+			t.Fatalf("Chunk size %d: expected descending order, got %v...", chunk, lines[:3])
+		}
+		out.Reset()
+
+	}
+
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Fatalf("Expected temporary files to be removed, found %d", len(files))
+	}
+
+	s := &ExternalSorter{ChunkSize: 2, TempDir: dir}
+	err := s.Sort(&bytes.Buffer{}, strings.NewReader(want[0].String()+"\n"+want[1].String()+"\n\nnope\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("Expected error on line 4, got %v", err)
+	}
+
+	s = &ExternalSorter{Binary: true}
+	if err := s.Sort(&bytes.Buffer{}, bytes.NewReader(make([]byte, 20))); err == nil {
+		t.Fatalf("Expected error for partial UUID")
+	}
+
+}
@@ -0,0 +1,24 @@
+package gouuidv6
+
+// 100ns intervals between the start of the Gregorian calendar (the UUID
+// epoch) and 1 January 1601 (the Windows FILETIME epoch)
+const filetimeOff = uint64(5748192000000000)
+
+// Return the time of the UUID as a Windows FILETIME: the number of
+// 100-nanosecond intervals since 1 January 1601 UTC, the same unit the UUID
+// stores its time in.  Returns 0 if the UUID is not version 6 or its time is
+// before 1601.
+func (u UUID) Filetime() uint64 {
+	if !u.isV6() {
+		return 0
+	}
+	ts := u.timestamp()
+	if ts < filetimeOff {
+		return 0
+	}
+	return ts - filetimeOff
+}
+
+// Return a new UUID with the time of the Windows FILETIME ft, using the
+// default Generator as NewFromTime does.
+func FromFiletime(ft uint64) UUID { return NewFromTime(tsToTime(ft + filetimeOff)) }
@@ -0,0 +1,26 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiletime(t *testing.T) {
+
+	// 2024-06-01T12:00:00Z as a FILETIME
+	ft := uint64(133617168000000000)
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	u := FromFiletime(ft)
+	if !u.Time().Equal(tim) {
+		t.Fatalf("Expected time %v from FILETIME, got %v", tim, u.Time())
+	}
+	if u.Filetime() != ft {
+		t.Fatalf("Expected FILETIME %d, got %d", ft, u.Filetime())
+	}
+
+	if u := FromFiletime(ft + 1); u.Filetime() != ft+1 {
+		t.Fatalf("Expected FILETIME to keep 100ns precision, got %d", u.Filetime())
+	}
+
+}
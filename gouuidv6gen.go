@@ -0,0 +1,663 @@
+package gouuidv6
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Generator creates UUIDs from its own node, clock sequence and time source.
+// The package level New and NewFromTime use a default Generator; create your
+// own with NewGenerator when you need IDs with a specific node or time.
+// A Generator is safe for concurrent use, and does not take any locks to
+// create a UUID.
+type Generator struct {
+	clock               atomic.Pointer[clockEpoch]
+	node                atomic.Uint64 // the node part
+	alwaysRandomizeNode atomic.Bool   // new random node for every UUID
+	now                 func() time.Time
+	counters            generatorCounters
+	onRegression        atomic.Pointer[func(prev, now time.Time, clockseq uint16)]
+	regressedFrom       atomic.Uint64 // timestamps of the last clock regression, see Healthz
+	regressedTo         atomic.Uint64
+	sinks               []func(u UUID)
+	csBase, csSize      uint32     // clock sequence partition, see WithClockSeqPartition
+	stateFile           *StateFile // where to save the clock state, if anywhere
+	precision           uint64     // timestamp precision in 100ns ticks, see WithPrecision
+	regressionTolerance uint64     // in 100ns ticks, see WithRegressionTolerance
+	driftThreshold      time.Duration
+	onDrift             func(drift time.Duration)
+	entropyPolicy       atomic.Int32
+	seedErr             error // error reading random data in NewGenerator, if any
+}
+
+// clockState is the last timestamp used and the clock sequence value.
+// ahead is set when the clock sequence ran out within a tick and lastts was
+// moved on past the clock to keep the UUIDs unique.
+type clockState struct {
+	lastts   uint64
+	clockseq uint32
+	ahead    bool
+}
+
+// clockEpoch holds a clockState packed into one word, so the timestamp and
+// clock sequence change together with a single compare-and-swap and without
+// allocating.  The word has the timestamp as an offset from base in its high
+// 49 bits (a range of about 1.8 years, starting half of that before the
+// epoch's first timestamp), then the ahead flag, and the clock sequence in
+// its low 14.
+// A timestamp outside that range, after a long time or from NewFromTime
+// with a distant time, starts a new epoch: the old one is retired by
+// swapping in retiredClockWord, which no state packs to, and replaced.
+type clockEpoch struct {
+	base uint64
+	word atomic.Uint64
+}
+
+const (
+	clockSeqMask     = 0x3fff
+	clockAheadBit    = 1 << 14
+	clockDeltaShift  = 15
+	clockDeltaMax    = 1<<(64-clockDeltaShift) - 1 // offsets must be below this
+	retiredClockWord = ^uint64(0)
+)
+
+// Return a new epoch holding st, with base well before st's timestamp.
+func newClockEpoch(st clockState) *clockEpoch {
+	e := &clockEpoch{}
+	if st.lastts > clockDeltaMax/2 {
+		e.base = st.lastts - clockDeltaMax/2
+	}
+	w, _ := e.pack(st)
+	e.word.Store(w)
+	return e
+}
+
+// Return the state packed in w.
+func (e *clockEpoch) unpack(w uint64) clockState {
+	return clockState{
+		lastts:   e.base + w>>clockDeltaShift,
+		clockseq: uint32(w & clockSeqMask),
+		ahead:    w&clockAheadBit != 0,
+	}
+}
+
+// Return st packed into a word, and false if its timestamp is outside the
+// epoch.
+func (e *clockEpoch) pack(st clockState) (uint64, bool) {
+	if st.lastts < e.base || st.lastts-e.base >= clockDeltaMax {
+		return 0, false
+	}
+	w := (st.lastts-e.base)<<clockDeltaShift | uint64(st.clockseq&clockSeqMask)
+	if st.ahead {
+		w |= clockAheadBit
+	}
+	return w, true
+}
+
+// generatorCounters are the live counters behind GeneratorMetrics.  Every
+// UUID counts in exactly one of newTicks and clockSeqIncrements, so creating
+// one only takes one atomic add, and their sum is the number generated.
+type generatorCounters struct {
+	newTicks, clockSeqIncrements, clockRegressions  atomic.Uint64
+	nodeRandomizations, entropyFailures, sinkErrors atomic.Uint64
+	driftWarnings                                   atomic.Uint64
+	drift                                           atomic.Int64
+}
+
+// GeneratorMetrics are counters of what a Generator has done since it was
+// created.  String returns them as JSON, so a GeneratorMetrics can be
+// published directly with expvar, e.g.:
+//
+//	expvar.Publish("uuids", expvar.Func(func() interface{} { return g.Metrics() }))
+type GeneratorMetrics struct {
+	Generated          uint64 `json:"generated"`           // UUIDs generated
+	ClockSeqIncrements uint64 `json:"clockseq_increments"` // times the clock sequence was incremented (same or earlier timestamp)
+	ClockRegressions   uint64 `json:"clock_regressions"`   // times the clock was found to have moved backward
+	NodeRandomizations uint64 `json:"node_randomizations"` // random nodes generated
+	EntropyFailures    uint64 `json:"entropy_failures"`    // failed reads of random data
+	SinkErrors         uint64 `json:"sink_errors"`         // failed writes to a WithSink writer
+	DriftWarnings      uint64 `json:"drift_warnings"`      // drift reports beyond the WithDriftThreshold threshold
+	ClockDrift         int64  `json:"clock_drift_ns"`      // last drift reported with ReportDrift, in nanoseconds
+}
+
+func (m GeneratorMetrics) String() string {
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+// GeneratorOption configures a Generator created with NewGenerator.
+type GeneratorOption func(g *Generator)
+
+// Return a new Generator.  Unless options say otherwise it uses a random
+// node (with the multicast bit set), a random starting clock sequence and
+// time.Now.
+func NewGenerator(opts ...GeneratorOption) *Generator {
+
+	g := &Generator{now: time.Now, csSize: 0x4000}
+
+	cs, err := randUint64()
+	if err != nil {
+		g.counters.entropyFailures.Add(1)
+		g.seedErr = err
+	}
+	g.setClock(clockState{clockseq: uint32(cs)})
+	node, err := randomNode()
+	g.counters.nodeRandomizations.Add(1)
+	if err != nil {
+		g.counters.entropyFailures.Add(1)
+		g.seedErr = err
+	}
+	g.node.Store(node)
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	// now the policy is known
+	if g.seedErr != nil && EntropyPolicy(g.entropyPolicy.Load()) == EntropyPanic {
+		panic(fmt.Sprintf("gouuidv6: cannot read random data: %v", g.seedErr))
+	}
+
+	return g
+}
+
+// WithNode sets the 48-bit node used by the Generator.  Higher bits are ignored.
+func WithNode(node uint64) GeneratorOption {
+	return func(g *Generator) { g.node.Store(node & 0x0000FFFFFFFFFFFF) }
+}
+
+// WithClockSeq sets the starting clock sequence of the Generator.  Combined
+// with WithNode and a fixed time this makes the generated UUIDs reproducible.
+func WithClockSeq(clockseq uint16) GeneratorOption {
+	return func(g *Generator) {
+		g.setClock(clockState{lastts: g.loadClock().lastts, clockseq: uint32(clockseq)})
+	}
+}
+
+// WithClockSeqPartition restricts the Generator to one of a number of equal,
+// non-overlapping ranges of clock sequence values, so that several Generators
+// sharing a node (for instance in different processes on the same host) can
+// never produce the same UUID.  slots must be between 1 and 16384 and slot
+// between 0 and slots-1; invalid values are ignored.  See LockSlot for a way
+// to hand out slots between processes.
+//
+// Each slot has 16384/slots clock sequence values, which bounds how many
+// UUIDs the Generator can create within one 100ns tick (or one interval of
+// WithPrecision): at most 16384 with 1 slot, 64 with 256 and 1 with 16384.
+// Once they run out the Generator moves the timestamp on to the next tick,
+// so the UUIDs stay unique but their times run ahead of the clock until it
+// catches up.
+func WithClockSeqPartition(slot, slots int) GeneratorOption {
+	return func(g *Generator) {
+		if slots < 1 || slots > 0x4000 || slot < 0 || slot >= slots {
+			return
+		}
+		g.csSize = uint32(0x4000 / slots)
+		g.csBase = uint32(slot) * g.csSize
+	}
+}
+
+// WithPrecision makes the Generator truncate times to a multiple of d, such as
+// time.Millisecond or time.Second, filling the rest of the timestamp with
+// random bits, so the UUIDs do not reveal exactly when they were created.
+// UUIDs still sort by time between intervals of d but not within one, and
+// Time returns a random time within the interval.  d of 100ns or less means
+// full precision.
+func WithPrecision(d time.Duration) GeneratorOption {
+	return func(g *Generator) {
+		g.precision = 0
+		if d > 100 {
+			g.precision = uint64(d / 100)
+		}
+	}
+}
+
+// WithRegressionTolerance makes the Generator only call the OnClockRegression
+// function when the clock moves backward by more than d, since small steps
+// (such as from NTP) are normal while big ones suggest the clock is
+// misconfigured.  The clock sequence is still incremented and the regression
+// counted in the metrics whatever the size of the step.
+func WithRegressionTolerance(d time.Duration) GeneratorOption {
+	return func(g *Generator) {
+		g.regressionTolerance = 0
+		if d > 0 {
+			g.regressionTolerance = uint64(d / 100)
+		}
+	}
+}
+
+// WithAlwaysRandomizeNode makes the Generator use a new random node for every
+// UUID, so that no two UUIDs can be linked to the same source by their node.
+func WithAlwaysRandomizeNode() GeneratorOption {
+	return func(g *Generator) { g.alwaysRandomizeNode.Store(true) }
+}
+
+// WithSink makes the Generator write every UUID it creates to w, in the
+// standard string form followed by a newline, for audit logs or capturing a
+// sequence to replay later.  Writes are serialized, and happen after each
+// UUID is created, so with concurrent callers they may not be in exactly
+// the order the UUIDs were generated.  Write errors are counted in the
+// Generator's metrics but otherwise ignored.
+func WithSink(w io.Writer) GeneratorOption {
+	return func(g *Generator) {
+		var mu sync.Mutex
+		buf := make([]byte, 0, 37)
+		g.sinks = append(g.sinks, func(u UUID) {
+			mu.Lock()
+			buf = append(u.EncodeText(buf[:0]), '\n')
+			_, err := w.Write(buf)
+			mu.Unlock()
+			if err != nil {
+				g.counters.sinkErrors.Add(1)
+			}
+		})
+	}
+}
+
+// WithCallback makes the Generator call fn with every UUID it creates, from
+// the goroutine that created it.
+func WithCallback(fn func(u UUID)) GeneratorOption {
+	return func(g *Generator) { g.sinks = append(g.sinks, fn) }
+}
+
+// WithTimeFunc sets the function the Generator calls to get the current time
+// in New.
+func WithTimeFunc(now func() time.Time) GeneratorOption {
+	return func(g *Generator) { g.now = now }
+}
+
+// WithMonotonicClock makes the Generator take the time from the monotonic
+// clock, as WithTimeFunc(MonotonicClock()).
+func WithMonotonicClock() GeneratorOption { return WithTimeFunc(MonotonicClock()) }
+
+// MonotonicClock returns a time function for WithTimeFunc or SetTimeFunc that
+// never goes backward.  It captures the current time once and adds the time
+// elapsed since according to the monotonic clock, so steps to the wall clock
+// (such as by NTP) do not cause bursts of clock sequence increments.  The
+// times can drift from the wall clock in a long running process.
+func MonotonicClock() func() time.Time {
+	start := time.Now()
+	return func() time.Time { return start.Add(time.Since(start)).Round(0) }
+}
+
+// Return a Generator that produces the same sequence of UUIDs every time it
+// is created with the same seed and start, for test fixtures and the like.
+// The node and starting clock sequence are derived from seed, and the clock
+// starts at start and advances by one 100ns tick for every call to New.
+// Options are applied afterwards and so can override any of these.  The
+// sequence is only reproducible if UUIDs are requested from one goroutine.
+func NewDeterministicGenerator(seed int64, start time.Time, opts ...GeneratorOption) *Generator {
+
+	r := mrand.New(mrand.NewSource(seed))
+
+	var ticks int64
+	g := &Generator{
+		csSize: 0x4000,
+		now: func() time.Time {
+			return start.Add(time.Duration(atomic.AddInt64(&ticks, 1)-1) * 100)
+		},
+	}
+	g.setClock(clockState{clockseq: uint32(r.Int63())})
+	// mask out high 2 bytes and set the multicast bit, as with randomNode
+	g.node.Store((uint64(r.Int63()) & 0x0000FFFFFFFFFFFF) | 0x0000010000000000)
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Clone returns a new Generator with the same configuration as g (node, time
+// function, options, callbacks and policies) but a new random clock
+// sequence and zeroed metrics, so parallel tests can each have an isolated
+// Generator without repeating its options.  opts are applied afterwards, as
+// with NewGenerator.  A StateFile is not shared with the clone.  Since the
+// clone has the same node, UUIDs from g and the clone are only kept apart by
+// their random clock sequences; give the clone its own node with WithNode if
+// they must never collide.
+func (g *Generator) Clone(opts ...GeneratorOption) *Generator {
+
+	c := &Generator{
+		now:                 g.now,
+		sinks:               append(([]func(u UUID))(nil), g.sinks...),
+		csBase:              g.csBase,
+		csSize:              g.csSize,
+		precision:           g.precision,
+		regressionTolerance: g.regressionTolerance,
+		driftThreshold:      g.driftThreshold,
+		onDrift:             g.onDrift,
+	}
+	c.node.Store(g.node.Load())
+	c.alwaysRandomizeNode.Store(g.alwaysRandomizeNode.Load())
+	c.onRegression.Store(g.onRegression.Load())
+	c.entropyPolicy.Store(g.entropyPolicy.Load())
+
+	cs, err := randUint64()
+	if err != nil {
+		c.counters.entropyFailures.Add(1)
+		c.seedErr = err
+	}
+	c.setClock(clockState{clockseq: uint32(cs)})
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.seedErr != nil && EntropyPolicy(c.entropyPolicy.Load()) == EntropyPanic {
+		panic(fmt.Sprintf("gouuidv6: cannot read random data: %v", c.seedErr))
+	}
+
+	return c
+}
+
+// Return the node from the MAC address of the named network interface, for
+// use with WithNode.
+func NodeFromInterface(name string) (uint64, error) {
+	i, err := net.InterfaceByName(name)
+	if err != nil {
+		return 0, err
+	}
+	if len(i.HardwareAddr) < 6 {
+		return 0, fmt.Errorf("interface %q has no usable hardware address", name)
+	}
+	return macNode(i.HardwareAddr), nil
+}
+
+// Return a new UUID with the current time from the Generator's time source.
+func (g *Generator) New() (ret UUID) {
+	g.newFromTime(&ret, g.now())
+	return ret
+}
+
+// Return a new UUID with the time t.
+func (g *Generator) NewFromTime(t time.Time) (ret UUID) {
+	g.newFromTime(&ret, t)
+	return ret
+}
+
+// Set ret to a new UUID with the time t, returning any error reading random
+// data for it.  The UUID is written in place rather than returned, so it is
+// not copied on the way back through each call.
+func (g *Generator) newFromTime(ret *UUID, t time.Time) error {
+
+	// NOTE: We intentionally ignore RFC 4122 section 4.2.1.2. and in the case
+	// that UUIDs are requested within the same 100-nanosecond time interval,
+	// we just increment the clock sequence - the same thing the RFC advises
+	// in the case of the clock moving backward (section 4.1.5).
+
+	// get current timestamp
+	tsval := g.tstime(t)
+
+	// if clock is the same as last time or moved backward, increment clockseq
+	for {
+		e := g.clock.Load()
+		old := e.word.Load()
+		if old == retiredClockWord {
+			continue // the epoch is being replaced
+		}
+		prev := e.unpack(old)
+		next := g.nextClock(prev, tsval)
+		if w, ok := e.pack(next); ok {
+			if e.word.CompareAndSwap(old, w) {
+				return g.newUUID(ret, next.lastts, prev.lastts, next.clockseq)
+			}
+			continue
+		}
+		if e.word.CompareAndSwap(old, retiredClockWord) {
+			g.clock.Store(newClockEpoch(next))
+			return g.newUUID(ret, next.lastts, prev.lastts, next.clockseq)
+		}
+	}
+
+}
+
+// Return the clock state after prev for a UUID with the timestamp tsval; its
+// lastts is the timestamp to use.  The clock sequence is kept within the
+// Generator's partition.  When it runs out within one tick the timestamp is
+// moved on to the next tick (of the precision) instead of wrapping around,
+// which would repeat a UUID, and the clock is treated as being on that tick
+// until it catches up.
+func (g *Generator) nextClock(prev clockState, tsval uint64) clockState {
+	cs := prev.clockseq
+	if cs >= g.csSize {
+		cs %= g.csSize // only until the first UUID, as the starting value is random
+	}
+	switch {
+	case tsval > prev.lastts:
+		return clockState{lastts: tsval, clockseq: cs}
+	case tsval < prev.lastts && !prev.ahead:
+		// the clock moved backward, a new clock sequence value keeps these
+		// UUIDs apart from the ones made the first time round
+		if cs++; cs == g.csSize {
+			cs = 0
+		}
+		return clockState{lastts: tsval, clockseq: cs}
+	}
+	if cs++; cs < g.csSize {
+		return clockState{lastts: prev.lastts, clockseq: cs, ahead: prev.ahead}
+	}
+	step := g.precision
+	if step < 1 {
+		step = 1
+	}
+	return clockState{lastts: prev.lastts + step, clockseq: 0, ahead: true}
+}
+
+// UnsafeGenerator creates UUIDs from a Generator without any of the
+// synchronization the Generator needs to be safe for concurrent use, for
+// batch jobs where a single goroutine owns it.  It keeps its own copy of the
+// clock state and plain counters, and hands them back to the Generator in
+// Release.
+type UnsafeGenerator struct {
+	g                                              *Generator
+	clock                                          clockState
+	node                                           uint64
+	randomizeNode                                  bool
+	newTicks, clockSeqIncrements, clockRegressions uint64
+}
+
+// Unsafe returns an UnsafeGenerator that continues g's clock sequence, for
+// use by one goroutine only.  g must not be used until the UnsafeGenerator
+// is released.
+func (g *Generator) Unsafe() *UnsafeGenerator {
+	return &UnsafeGenerator{
+		g:             g,
+		clock:         g.loadClock(),
+		node:          g.node.Load(),
+		randomizeNode: g.alwaysRandomizeNode.Load(),
+	}
+}
+
+// Return a new UUID with the current time from the Generator's time source.
+func (u *UnsafeGenerator) New() (ret UUID) {
+
+	g := u.g
+	prev := u.clock
+	next := g.nextClock(prev, g.tstime(g.now()))
+	u.clock = next
+
+	if prev.lastts < next.lastts {
+		u.newTicks++
+	} else {
+		u.clockSeqIncrements++
+		if prev.lastts > next.lastts {
+			u.clockRegressions++
+			g.reportRegression(prev.lastts, next.lastts, g.csBase+next.clockseq)
+		}
+	}
+
+	g.buildUUID(&ret, next.lastts, next.clockseq, u.node, u.randomizeNode)
+	return ret
+}
+
+// Release hands the clock state and counters back to the Generator, which can
+// be used again afterwards.  The UnsafeGenerator must not be used after it is
+// released.
+func (u *UnsafeGenerator) Release() {
+	g := u.g
+	g.setClock(u.clock)
+	g.counters.newTicks.Add(u.newTicks)
+	g.counters.clockSeqIncrements.Add(u.clockSeqIncrements)
+	g.counters.clockRegressions.Add(u.clockRegressions)
+	*u = UnsafeGenerator{}
+}
+
+// Replace the clock state, while the Generator is not in use.
+func (g *Generator) setClock(st clockState) { g.clock.Store(newClockEpoch(st)) }
+
+// Return the current clock state.
+func (g *Generator) loadClock() clockState {
+	for {
+		e := g.clock.Load()
+		if w := e.word.Load(); w != retiredClockWord {
+			return e.unpack(w)
+		}
+	}
+}
+
+// Set ret to the UUID for the timestamp tsval and clock sequence value
+// clockseq, given the timestamp used for the previous UUID, updating the
+// counters and calling the regression callback and sinks as needed.  The
+// error is from reading random data, in which case the fallback source was
+// used instead.
+func (g *Generator) newUUID(ret *UUID, tsval, prevts uint64, clockseq uint32) error {
+
+	if prevts < tsval {
+		g.counters.newTicks.Add(1)
+	} else {
+		g.clockSeqIncremented(prevts, tsval, g.csBase+clockseq)
+	}
+
+	return g.buildUUID(ret, tsval, clockseq, g.node.Load(), g.alwaysRandomizeNode.Load())
+
+}
+
+// Set ret to the UUID for the timestamp tsval, clock sequence value clockseq
+// and node (or a random one), saving the state and calling the sinks.
+func (g *Generator) buildUUID(ret *UUID, tsval uint64, clockseq uint32, node uint64, randomizeNode bool) (err error) {
+
+	cs := g.csBase + clockseq
+
+	if g.stateFile != nil {
+		g.stateFile.save(tsval, clockseq)
+	}
+
+	if randomizeNode {
+		node, err = g.randomNode()
+	}
+
+	if g.precision > 1 {
+		r, rerr := randUint64()
+		if rerr != nil {
+			g.entropyFailure(rerr)
+			err = rerr
+		}
+		tsval += r % g.precision
+	}
+
+	// 2 bit variant, 14 bits clock sequence, 48 bits node
+	bigEnd.PutUint64(ret[:8], tshi(tsval))
+	bigEnd.PutUint64(ret[8:], (uint64(0x8000)<<48)|(uint64(cs&0x3fff)<<48)|node)
+
+	for _, sink := range g.sinks {
+		sink(*ret)
+	}
+
+	return err
+
+}
+
+// Count a UUID that needed the clock sequence incremented, as the clock had
+// not moved on from prevts to tsval, and report a regression if it moved
+// backward.
+func (g *Generator) clockSeqIncremented(prevts, tsval uint64, cs uint32) {
+	g.counters.clockSeqIncrements.Add(1)
+	if prevts > tsval {
+		g.counters.clockRegressions.Add(1)
+		g.reportRegression(prevts, tsval, cs)
+	}
+}
+
+// Record the clock moving backward from prevts to tsval for Healthz, and call
+// the regression callback, if any, if it moved by more than the tolerance.
+func (g *Generator) reportRegression(prevts, tsval uint64, cs uint32) {
+	g.regressedFrom.Store(prevts)
+	g.regressedTo.Store(tsval)
+	if fn := g.onRegression.Load(); fn != nil && prevts-tsval > g.regressionTolerance {
+		(*fn)(tsToTime(prevts), tsToTime(tsval), uint16(cs&0x3fff))
+	}
+}
+
+// WriteN generates n UUIDs and writes them to w in the standard string form,
+// each followed by sep (e.g. '\n' for one per line).  Output is buffered and
+// no slice of UUIDs is built, so it is suitable for writing millions of IDs
+// to seed a database.  It stops at the first write error.
+func (g *Generator) WriteN(w io.Writer, n int, sep byte) error {
+	bw := bufio.NewWriterSize(w, 32*1024)
+	buf := make([]byte, 0, 37)
+	for i := 0; i < n; i++ {
+		buf = append(g.New().EncodeText(buf[:0]), sep)
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Return the timestamp for t, truncated to the Generator's precision.  Within
+// an interval of the precision the clock sequence keeps UUIDs unique, and the
+// random part of the timestamp is only filled in when the UUID is created.
+func (g *Generator) tstime(t time.Time) uint64 {
+	tsval := tstime(t)
+	if g.precision > 1 {
+		tsval -= tsval % g.precision
+	}
+	return tsval
+}
+
+// Set a function to be called whenever the Generator sees the clock move
+// backward, with the previous and new times and the clock sequence that was
+// used to keep the new UUID unique.  It is called after the UUID is created,
+// from the goroutine that created it.  Pass nil to remove it.
+func (g *Generator) OnClockRegression(fn func(prev, now time.Time, clockseq uint16)) {
+	if fn == nil {
+		g.onRegression.Store(nil)
+		return
+	}
+	g.onRegression.Store(&fn)
+}
+
+// Return a snapshot of the Generator's counters.
+func (g *Generator) Metrics() GeneratorMetrics {
+	c := &g.counters
+	incs := c.clockSeqIncrements.Load()
+	return GeneratorMetrics{
+		Generated:          c.newTicks.Load() + incs,
+		ClockSeqIncrements: incs,
+		ClockRegressions:   c.clockRegressions.Load(),
+		NodeRandomizations: c.nodeRandomizations.Load(),
+		EntropyFailures:    c.entropyFailures.Load(),
+		SinkErrors:         c.sinkErrors.Load(),
+		DriftWarnings:      c.driftWarnings.Load(),
+		ClockDrift:         c.drift.Load(),
+	}
+}
+
+// Return a random node, counting it (and any failure to read entropy) in
+// the Generator's metrics.
+func (g *Generator) randomNode() (uint64, error) {
+	node, err := randomNode()
+	g.counters.nodeRandomizations.Add(1)
+	if err != nil {
+		g.entropyFailure(err)
+	}
+	return node, err
+}
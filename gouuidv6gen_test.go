@@ -0,0 +1,434 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerator(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	g := NewGenerator(WithNode(0xdeadbeef), WithClockSeq(5), WithTimeFunc(func() time.Time { return tim }))
+
+	u1, u2 := g.New(), g.New()
+
+	if u1.String() != `1ef200e7-8cb6-6000-8005-0000deadbeef` {
+		t.Fatalf("Unexpected first UUID: %v", u1)
+	}
+
+	// same time, so the clock sequence should have moved on
+	if u2.String() != `1ef200e7-8cb6-6000-8006-0000deadbeef` {
+		t.Fatalf("Unexpected second UUID: %v", u2)
+	}
+
+	if !u1.Time().Equal(tim) {
+		t.Fatalf("Expected time %v, got %v", tim, u1.Time())
+	}
+
+	// a fresh generator with the same options gives the same sequence
+	g2 := NewGenerator(WithNode(0xdeadbeef), WithClockSeq(5))
+	if u := g2.NewFromTime(tim); u != u1 {
+		t.Fatalf("Expected %v from second generator, got %v", u1, u)
+	}
+
+	// default random node should have the multicast bit set
+	if n := NewGenerator().New().Node(); n[0]&0x01 == 0 {
+		t.Fatalf("Expected multicast bit set in random node %v", n)
+	}
+
+}
+
+func TestDeterministicGenerator(t *testing.T) {
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	g1 := NewDeterministicGenerator(42, start)
+	g2 := NewDeterministicGenerator(42, start)
+	g3 := NewDeterministicGenerator(43, start)
+
+	var last UUID
+	for i := 0; i < 100; i++ {
+		u1, u2, u3 := g1.New(), g2.New(), g3.New()
+		if u1 != u2 {
+			t.Fatalf("Same seed gave different UUIDs at %d: %v, %v", i, u1, u2)
+		}
+		if u1 == u3 {
+			t.Fatalf("Different seed gave the same UUID at %d: %v", i, u1)
+		}
+		if want := start.Add(time.Duration(i) * 100); !u1.Time().Equal(want) {
+			t.Fatalf("Expected time %v at %d, got %v", want, i, u1.Time())
+		}
+		if i > 0 && bytes.Compare(last[:], u1[:]) >= 0 {
+			t.Fatalf("UUIDs out of sequence at %d: %v, %v", i, last, u1)
+		}
+		last = u1
+	}
+
+}
+
+func TestGeneratorMetrics(t *testing.T) {
+
+	tim := time.Now()
+	g := NewGenerator()
+
+	g.NewFromTime(tim)
+	g.NewFromTime(tim)                   // same time
+	g.NewFromTime(tim.Add(-time.Second)) // backwards
+	g.NewFromTime(tim.Add(time.Second))
+
+	m := g.Metrics()
+	want := GeneratorMetrics{Generated: 4, ClockSeqIncrements: 2, ClockRegressions: 1, NodeRandomizations: 1}
+	if m != want {
+		t.Fatalf("Expected metrics %+v, got %+v", want, m)
+	}
+
+	if s := m.String(); !strings.Contains(s, `"clock_regressions":1`) {
+		t.Fatalf("Unexpected JSON for metrics: %s", s)
+	}
+
+	g = NewGenerator(WithAlwaysRandomizeNode())
+	u1, u2 := g.New(), g.New()
+	if bytes.Equal(u1.Node(), u2.Node()) {
+		t.Fatalf("Expected different nodes with WithAlwaysRandomizeNode, got %v twice", u1.Node())
+	}
+	if n := g.Metrics().NodeRandomizations; n != 3 {
+		t.Fatalf("Expected 3 node randomizations, got %d", n)
+	}
+
+}
+
+func TestClockEpoch(t *testing.T) {
+
+	// times too far apart to share an epoch
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	then := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithClockSeq(100))
+
+	var prevs []time.Time
+	g.OnClockRegression(func(p, n time.Time, c uint16) { prevs = append(prevs, p) })
+
+	for i, c := range []struct {
+		t  time.Time
+		cs uint16
+	}{
+		{now, 100},
+		{then, 101}, // backward to a new epoch
+		{then, 102},
+		{now, 102}, // forward to a new epoch
+		{now, 103},
+	} {
+		u := g.NewFromTime(c.t)
+		if !u.Time().Equal(c.t) || u.ClockSeq() != c.cs {
+			t.Fatalf("Expected time %v and clock sequence %d at %d, got %v and %d", c.t, c.cs, i, u.Time(), u.ClockSeq())
+		}
+	}
+	if len(prevs) != 1 || !prevs[0].Equal(now) {
+		t.Fatalf("Expected one regression from %v, got %v", now, prevs)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { g.New() }); n != 0 {
+		t.Fatalf("Expected no allocations for New, got %v", n)
+	}
+
+}
+
+func TestClockSeqPartitionWrap(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixed := func() time.Time { return tim }
+
+	for _, slots := range []int{0x4000, 256, 1} {
+		g := NewGenerator(WithClockSeqPartition(3, slots), WithTimeFunc(fixed))
+		g.OnClockRegression(func(p, n time.Time, c uint16) {
+			t.Fatalf("Unexpected regression from %v to %v with %d slots", p, n, slots)
+		})
+
+		seen := make(map[UUID]bool)
+		var last UUID
+		for i := 0; i < 3*0x4000/slots; i++ {
+			u := g.New()
+			if seen[u] {
+				t.Fatalf("Duplicate UUID %v at %d with %d slots", u, i, slots)
+			}
+			seen[u] = true
+			if u.Time().Before(last.Time()) {
+				t.Fatalf("UUID %v is earlier than the one before it with %d slots", u, slots)
+			}
+			last = u
+		}
+		if last.Time().Sub(tim) > 300*time.Nanosecond {
+			t.Fatalf("Expected the time to move on at most 3 ticks with %d slots, got %v", slots, last.Time().Sub(tim))
+		}
+
+		// back on the clock once it catches up
+		later := tim.Add(time.Microsecond)
+		if u := g.NewFromTime(later); !u.Time().Equal(later) {
+			t.Fatalf("Expected time %v once the clock caught up, got %v", later, u.Time())
+		}
+	}
+
+}
+
+func TestClockRegression(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithClockSeq(10))
+
+	var calls int
+	var prev, now time.Time
+	var cs uint16
+	g.OnClockRegression(func(p, n time.Time, c uint16) {
+		calls++
+		prev, now, cs = p, n, c
+	})
+
+	g.NewFromTime(tim)
+	g.NewFromTime(tim) // same time is not a regression
+	u := g.NewFromTime(tim.Add(-time.Minute))
+
+	if calls != 1 {
+		t.Fatalf("Expected 1 regression callback, got %d", calls)
+	}
+	if !prev.Equal(tim) || !now.Equal(tim.Add(-time.Minute)) || cs != 12 || cs != u.ClockSeq() {
+		t.Fatalf("Unexpected callback arguments: prev=%v now=%v clockseq=%d (UUID %v)", prev, now, cs, u)
+	}
+
+	g.OnClockRegression(nil)
+	g.NewFromTime(tim.Add(-time.Hour))
+	if calls != 1 {
+		t.Fatalf("Callback should not be called after being removed")
+	}
+
+}
+
+func TestSink(t *testing.T) {
+
+	var buf bytes.Buffer
+	var called []UUID
+	g := NewGenerator(WithSink(&buf), WithCallback(func(u UUID) { called = append(called, u) }))
+
+	u1, u2 := g.New(), g.New()
+
+	if want := u1.String() + "\n" + u2.String() + "\n"; buf.String() != want {
+		t.Fatalf("Expected sink to get:\n%s\ngot:\n%s", want, buf.String())
+	}
+	if len(called) != 2 || called[0] != u1 || called[1] != u2 {
+		t.Fatalf("Expected callback with %v and %v, got %v", u1, u2, called)
+	}
+
+	g = NewGenerator(WithSink(failWriter{}))
+	g.New()
+	if n := g.Metrics().SinkErrors; n != 1 {
+		t.Fatalf("Expected 1 sink error, got %d", n)
+	}
+
+	g = NewGenerator(WithSink(io.Discard))
+	if n := testing.AllocsPerRun(100, func() { g.New() }); n != 0 {
+		t.Fatalf("Expected no allocations with a sink, got %v", n)
+	}
+
+}
+
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) { return 0, io.ErrShortWrite }
+
+func TestWriteN(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithNode(0xdeadbeef), WithClockSeq(5), WithTimeFunc(func() time.Time { return tim }))
+
+	var buf bytes.Buffer
+	if err := g.WriteN(&buf, 3, '\n'); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "1ef200e7-8cb6-6000-8005-0000deadbeef\n1ef200e7-8cb6-6000-8006-0000deadbeef\n1ef200e7-8cb6-6000-8007-0000deadbeef\n"
+	if buf.String() != exp {
+		t.Fatalf("Unexpected WriteN output:\n%s", buf.String())
+	}
+
+	if err := g.WriteN(failWriter{}, 1, '\n'); err == nil {
+		t.Fatalf("Expected write error")
+	}
+
+}
+
+func TestUnsafeGenerator(t *testing.T) {
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g1 := NewDeterministicGenerator(42, start)
+	g2 := NewDeterministicGenerator(42, start)
+
+	var u *UnsafeGenerator
+	for i := 0; i < 100; i++ {
+		// same time repeatedly, so the clock sequence is exercised as well,
+		// and the state handed back and forth between g2 and u
+		if i%10 == 0 {
+			if u != nil {
+				u.Release()
+			}
+			g1.NewFromTime(start)
+			g2.NewFromTime(start)
+			u = g2.Unsafe()
+		}
+		if u1, u2 := g1.New(), u.New(); u1 != u2 {
+			t.Fatalf("UnsafeGenerator gave %v instead of %v at %d", u2, u1, i)
+		}
+	}
+	u.Release()
+
+	if m1, m2 := g1.Metrics(), g2.Metrics(); m1 != m2 {
+		t.Fatalf("Expected the same metrics, got %+v and %+v", m1, m2)
+	}
+	if u1, u2 := g1.New(), g2.New(); u1 != u2 {
+		t.Fatalf("Expected the released Generator to continue with %v, got %v", u1, u2)
+	}
+
+}
+
+func BenchmarkUnsafeGeneratorNew(b *testing.B) {
+	g := NewGenerator().Unsafe()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.New()
+	}
+}
+
+func BenchmarkGeneratorNew(b *testing.B) {
+	g := NewGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.New()
+	}
+}
+
+// The generator's own cost, without reading the clock: the same time every
+// call, so every UUID takes the clock sequence increment path.
+func BenchmarkGeneratorNewFromTime(b *testing.B) {
+	g := NewGenerator()
+	t := time.Now()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.NewFromTime(t)
+	}
+}
+
+func BenchmarkGeneratorNewParallel(b *testing.B) {
+	g := NewGenerator()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.New()
+		}
+	})
+}
+
+func TestPrecision(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithPrecision(time.Second))
+
+	seen := make(map[UUID]bool)
+	var times []time.Time
+	for i := 0; i < 100; i++ {
+		u := g.NewFromTime(tim.Add(time.Duration(i) * 10 * time.Millisecond))
+		if seen[u] {
+			t.Fatalf("Duplicate UUID %v", u)
+		}
+		seen[u] = true
+		if ut := u.Time(); ut.Before(tim) || !ut.Before(tim.Add(time.Second)) {
+			t.Fatalf("Expected time within a second of %v, got %v", tim, ut)
+		}
+		times = append(times, u.Time())
+	}
+
+	if times[0].Equal(times[1]) && times[1].Equal(times[2]) {
+		t.Fatalf("Expected random times within the second, got %v", times[:3])
+	}
+	if m := g.Metrics(); m.ClockRegressions != 0 || m.ClockSeqIncrements != 99 {
+		t.Fatalf("Expected the same truncated time to increment the clock sequence, got %+v", m)
+	}
+
+	if u := g.NewFromTime(tim.Add(time.Second)); u.Time().Before(tim.Add(time.Second)) {
+		t.Fatalf("Expected a time in the next second, got %v", u.Time())
+	}
+
+}
+
+func TestMonotonicClock(t *testing.T) {
+
+	now := MonotonicClock()
+	last := now()
+	if d := time.Since(last); d < 0 || d > time.Second {
+		t.Fatalf("Expected monotonic time close to now, got %v", last)
+	}
+	for i := 0; i < 1000; i++ {
+		tim := now()
+		if tim.Before(last) {
+			t.Fatalf("Monotonic clock went backward from %v to %v", last, tim)
+		}
+		last = tim
+	}
+
+	g := NewGenerator(WithMonotonicClock())
+	if u := g.New(); time.Since(u.Time()) > time.Second {
+		t.Fatalf("Unexpected time %v", u.Time())
+	}
+
+}
+
+func TestRegressionTolerance(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithRegressionTolerance(time.Second))
+
+	var calls int
+	g.OnClockRegression(func(prev, now time.Time, cs uint16) { calls++ })
+
+	g.NewFromTime(tim)
+	g.NewFromTime(tim.Add(-500 * time.Millisecond))
+	if calls != 0 {
+		t.Fatalf("Expected no callback for a regression within the tolerance")
+	}
+	g.NewFromTime(tim.Add(-2 * time.Second))
+	if calls != 1 {
+		t.Fatalf("Expected a callback for a regression beyond the tolerance, got %d", calls)
+	}
+	if m := g.Metrics(); m.ClockRegressions != 2 {
+		t.Fatalf("Expected both regressions to be counted, got %+v", m)
+	}
+
+}
+
+func TestClone(t *testing.T) {
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var sunk []UUID
+	g := NewGenerator(WithNode(0x0242ac110002), WithTimeFunc(func() time.Time { return start }), WithCallback(func(u UUID) { sunk = append(sunk, u) }))
+	g.New()
+
+	c := g.Clone()
+	u := c.New()
+	if !u.Time().Equal(start) || u.Node().String() != "02:42:ac:11:00:02" {
+		t.Fatalf("Expected clone to keep time function and node, got %v", u)
+	}
+	if len(sunk) != 2 || sunk[1] != u {
+		t.Fatalf("Expected clone to keep callback, got %v", sunk)
+	}
+	if m := c.Metrics(); m.Generated != 1 {
+		t.Fatalf("Expected fresh metrics for clone, got %v", m)
+	}
+	if g.Metrics().Generated != 1 {
+		t.Fatalf("Expected clone not to affect original metrics")
+	}
+
+	if u := g.Clone(WithNode(7)).New(); u.Node().String() != "00:00:00:00:00:07" {
+		t.Fatalf("Expected options applied to clone, got node %v", u.Node())
+	}
+
+}
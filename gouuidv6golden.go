@@ -0,0 +1,102 @@
+package gouuidv6
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// GoldenRecord is one case of the conformance corpus from GoldenCorpus: the
+// fields of a UUID and its expected encodings.
+type GoldenRecord struct {
+	Name      string `json:"name"`
+	Timestamp uint64 `json:"timestamp"` // 60-bit Gregorian timestamp, see GregorianTimestamp
+	ClockSeq  uint16 `json:"clockseq"`  // 14 bits
+	Node      uint64 `json:"node"`      // 48 bits
+	Time      string `json:"time"`      // RFC 3339 UTC time, "" if out of range for time.Time
+	String    string `json:"string"`    // UUID.String
+	Base64    string `json:"base64"`    // UUIDB64.String
+	Bytes     string `json:"bytes"`     // the 16 bytes in hex
+}
+
+// Most 100ns ticks from the Unix epoch tsToTime can convert, as it works in
+// int64 nanoseconds.
+const maxTimeTicks = uint64(1<<63-1) / 100
+
+// GoldenCorpus returns a deterministic set of cases covering the edges of
+// each field (the start of the Gregorian calendar and the Unix epoch, the
+// largest timestamp, all clock sequence bits, multicast and maximum nodes)
+// and a spread of values between, so implementations of version 6 UUIDs in
+// other languages can be tested against this package.  The corpus only ever
+// grows; existing cases do not change.
+func GoldenCorpus() []GoldenRecord {
+
+	type fields struct {
+		name     string
+		ts       uint64
+		clockseq uint16
+		node     uint64
+	}
+	cases := []fields{
+		{"zero", 0, 0, 0},
+		{"unix epoch", tsoff, 0, 0},
+		{"max timestamp", 0x0FFFFFFFFFFFFFFF, 0, 0},
+		{"low 12 timestamp bits", tsoff | 0xFFF, 0, 0},
+		{"timestamp bit 12", tsoff&^0xFFF + 0x1000, 0, 0},
+		{"max clockseq", tsoff, 0x3FFF, 0},
+		{"clockseq high bit", tsoff, 0x2000, 0},
+		{"multicast node", tsoff, 0, 0x010000000000},
+		{"max node", tsoff, 0, 0xFFFFFFFFFFFF},
+		{"all bits", 0x0FFFFFFFFFFFFFFF, 0x3FFF, 0xFFFFFFFFFFFF},
+		{"2000-01-01", tstime(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 0x1234, 0x00163e5e6c00},
+	}
+
+	// a spread of values from splitmix64, which is simple to reproduce
+	x := uint64(0x6f75696476360000)
+	next := func() uint64 {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		return z ^ z>>31
+	}
+	for i := 0; i < 16; i++ {
+		ts := next() >> 4
+		if i%2 == 0 {
+			// after the Unix epoch, within time.Time's range
+			ts = tsoff + ts>>4
+		}
+		cases = append(cases, fields{"random " + string(rune('a'+i)), ts, uint16(next() >> 50), next() >> 16})
+	}
+
+	ret := make([]GoldenRecord, 0, len(cases))
+	for _, c := range cases {
+		u := FromGregorianTimestamp(c.ts, c.clockseq, c.node)
+		r := GoldenRecord{
+			Name:      c.name,
+			Timestamp: c.ts,
+			ClockSeq:  c.clockseq,
+			Node:      c.node,
+			String:    u.String(),
+			Base64:    UUIDB64(u).String(),
+			Bytes:     hex.EncodeToString(u[:]),
+		}
+		if c.ts+maxTimeTicks >= tsoff && c.ts <= tsoff+maxTimeTicks {
+			r.Time = tsToTime(c.ts).UTC().Format(time.RFC3339Nano)
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+// WriteGoldenCorpus writes GoldenCorpus to w as JSON, one record per line.
+func WriteGoldenCorpus(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, r := range GoldenCorpus() {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
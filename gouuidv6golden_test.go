@@ -0,0 +1,71 @@
+package gouuidv6
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGoldenCorpus(t *testing.T) {
+
+	corpus := GoldenCorpus()
+
+	// fixed cases that must never change
+	for _, want := range []GoldenRecord{
+		{Name: "zero", String: "00000000-0000-6000-8000-000000000000", Base64: "--------N-1-----------", Bytes: "00000000000060008000000000000000"},
+		{Name: "all bits", String: "ffffffff-ffff-6fff-bfff-ffffffffffff"},
+	} {
+		var got GoldenRecord
+		for _, r := range corpus {
+			if r.Name == want.Name {
+				got = r
+			}
+		}
+		if got.String != want.String || (want.Base64 != "" && got.Base64 != want.Base64) || (want.Bytes != "" && got.Bytes != want.Bytes) {
+			t.Fatalf("Expected %+v, got %+v", want, got)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, r := range corpus {
+		if names[r.Name] {
+			t.Fatalf("Duplicate case name %q", r.Name)
+		}
+		names[r.Name] = true
+
+		u, err := Parse(r.String)
+		if err != nil || u.GregorianTimestamp() != r.Timestamp || u.ClockSeq() != r.ClockSeq {
+			t.Fatalf("Case %q does not round trip: %v (err=%v)", r.Name, u, err)
+		}
+		if b, _ := ParseB64(r.Base64); UUID(b) != u {
+			t.Fatalf("Case %q base64 %s does not match %v", r.Name, r.Base64, u)
+		}
+		if r.Time != "" {
+			if tm, err := time.Parse(time.RFC3339Nano, r.Time); err != nil || !tm.Equal(u.Time()) {
+				t.Fatalf("Case %q time %s does not match %v", r.Name, r.Time, u.Time())
+			}
+		}
+	}
+	if !names["unix epoch"] || corpus[1].Time != "1970-01-01T00:00:00Z" {
+		t.Fatalf("Expected Unix epoch case, got %+v", corpus[1])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGoldenCorpus(&buf); err != nil {
+		t.Fatal(err)
+	}
+	sc := bufio.NewScanner(&buf)
+	n := 0
+	for ; sc.Scan(); n++ {
+		var r GoldenRecord
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil || r != corpus[n] {
+			t.Fatalf("Line %d does not match corpus: %s", n+1, sc.Bytes())
+		}
+	}
+	if n != len(corpus) {
+		t.Fatalf("Expected %d lines, got %d", len(corpus), n)
+	}
+
+}
@@ -0,0 +1,47 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"time"
+)
+
+// How far the clock may have moved backward before Healthz reports a
+// problem.
+const healthzMaxRegression = time.Second
+
+// Healthz checks the default Generator; see Generator.Healthz.
+func Healthz() error { return defaultGenerator.Healthz() }
+
+// Healthz returns an error if the Generator is unable to produce good UUIDs:
+// random data cannot be read, the node is zero, the Generator saw the clock
+// move backward by more than a second and it has not yet caught up with
+// where it was, or the drift reported with ReportDrift is beyond the
+// WithDriftThreshold threshold.  UUIDs created with NewFromTime for a time
+// in the future do not count unless the clock is then seen going back from
+// them.  Suitable for use in a readiness probe.
+func (g *Generator) Healthz() error {
+
+	if _, err := randUint64(); err != nil {
+		g.counters.entropyFailures.Add(1)
+		return fmt.Errorf("gouuidv6: cannot read random data: %v", err)
+	}
+
+	now := tstime(g.now())
+
+	node, always := g.node.Load(), g.alwaysRandomizeNode.Load()
+
+	if node == 0 && !always {
+		return fmt.Errorf("gouuidv6: node is zero")
+	}
+
+	if drift := time.Duration(g.counters.drift.Load()); g.driftExceeded(drift) {
+		return fmt.Errorf("gouuidv6: clock drift of %v is more than %v", drift, g.driftThreshold)
+	}
+
+	from, to := g.regressedFrom.Load(), g.regressedTo.Load()
+	if step := tsToTime(from).Sub(tsToTime(to)); from > now && step > healthzMaxRegression {
+		return fmt.Errorf("gouuidv6: clock moved backward by %v, from %v", step, tsToTime(from).UTC())
+	}
+
+	return nil
+}
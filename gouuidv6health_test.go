@@ -0,0 +1,49 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealthz(t *testing.T) {
+
+	if err := Healthz(); err != nil {
+		t.Fatalf("Default generator should be healthy: %v", err)
+	}
+
+	now := time.Now()
+	g := NewGenerator(WithTimeFunc(func() time.Time { return now }))
+	g.New()
+	if err := g.Healthz(); err != nil {
+		t.Fatalf("Generator should be healthy: %v", err)
+	}
+
+	// a UUID for a future time is not a regression
+	g2 := NewGenerator()
+	g2.NewFromTime(time.Now().Add(time.Hour))
+	if err := g2.Healthz(); err != nil {
+		t.Fatalf("Generator should be healthy after a UUID for a future time: %v", err)
+	}
+
+	// small regression is fine, big one is not until the clock catches up
+	g = NewGenerator(WithTimeFunc(func() time.Time { return now }))
+	g.NewFromTime(now.Add(500 * time.Millisecond))
+	g.New()
+	if err := g.Healthz(); err != nil {
+		t.Fatalf("Generator should tolerate a small regression: %v", err)
+	}
+	g.NewFromTime(now.Add(time.Minute))
+	g.New()
+	if err := g.Healthz(); err == nil {
+		t.Fatalf("Generator should report the clock going back a minute")
+	}
+	now = now.Add(2 * time.Minute)
+	if err := g.Healthz(); err != nil {
+		t.Fatalf("Generator should be healthy once the clock caught up: %v", err)
+	}
+
+	if err := NewGenerator(WithNode(0)).Healthz(); err == nil {
+		t.Fatalf("Generator should report a zero node")
+	}
+
+}
@@ -0,0 +1,168 @@
+package gouuidv6
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// IDHandler is an http.Handler that responds to GET requests with new UUIDs,
+// for scripts and legacy systems that need server-issued IDs.  It ignores the
+// request path, so it can be mounted anywhere in an existing service's mux.
+//
+// The "count" query parameter asks for more than one, and "format=b64"
+// selects the UUIDB64 form instead of the standard hex.  The response is one
+// UUID per line as text/plain, or a JSON array of strings as
+// application/json, chosen from the Accept header or overridden by the
+// "type" query parameter ("text" or "json").
+type IDHandler struct {
+	// Generator used to create the UUIDs, the package default if nil.
+	Generator *Generator
+	// Most UUIDs returned for one request, 1000 if zero.
+	MaxCount int
+}
+
+func (h *IDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+
+	limit := h.MaxCount
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	count := 1
+	if s := q.Get("count"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > limit {
+			http.Error(w, "count must be between 1 and "+strconv.Itoa(limit), http.StatusBadRequest)
+			return
+		}
+		count = n
+	}
+
+	var str func(u UUID) string
+	switch q.Get("format") {
+	case "", "hex":
+		str = UUID.String
+	case "b64":
+		str = func(u UUID) string { return UUIDB64(u).String() }
+	default:
+		http.Error(w, "format must be hex or b64", http.StatusBadRequest)
+		return
+	}
+
+	var asJSON bool
+	switch q.Get("type") {
+	case "":
+		t, ok := negotiate(r.Header.Get("Accept"))
+		if !ok {
+			http.Error(w, "acceptable types are text/plain and application/json", http.StatusNotAcceptable)
+			return
+		}
+		asJSON = t == "application/json"
+	case "text":
+	case "json":
+		asJSON = true
+	default:
+		http.Error(w, "type must be text or json", http.StatusBadRequest)
+		return
+	}
+
+	if asJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Header().Set("Vary", "Accept")
+	w.Header().Set("Cache-Control", "no-store")
+
+	// a HEAD request gets the headers without using up any UUIDs
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	g := h.Generator
+	if g == nil {
+		g = defaultGenerator
+	}
+
+	var b []byte
+	if asJSON {
+		b = make([]byte, 0, count*39+2)
+		b = append(b, '[')
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '"')
+			b = append(b, str(g.New())...)
+			b = append(b, '"')
+		}
+		b = append(b, ']', '\n')
+	} else {
+		b = make([]byte, 0, count*37)
+		for i := 0; i < count; i++ {
+			b = append(b, str(g.New())...)
+			b = append(b, '\n')
+		}
+	}
+
+	w.Write(b)
+}
+
+// Return the media type IDHandler should respond with for the Accept header
+// accept: the supported type with the highest quality, text/plain if they
+// are equal or accept is empty, and false if neither is acceptable.
+func negotiate(accept string) (string, bool) {
+
+	if strings.TrimSpace(accept) == "" {
+		return "text/plain", true
+	}
+
+	// quality of each supported type, -1 if not mentioned, by the most
+	// specific matching media range
+	types := [2]string{"text/plain", "application/json"}
+	qs, spec := [2]float64{-1, -1}, [2]int{-1, -1}
+
+	for _, mr := range strings.Split(accept, ",") {
+		params := strings.Split(mr, ";")
+		mt := strings.ToLower(strings.TrimSpace(params[0]))
+		q := 1.0
+		for _, p := range params[1:] {
+			if k, v, ok := strings.Cut(strings.TrimSpace(p), "="); ok && strings.TrimSpace(k) == "q" {
+				if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					q = f
+				}
+			}
+		}
+		for i, t := range types {
+			n := -1
+			switch {
+			case mt == t:
+				n = 2
+			case mt == t[:strings.IndexByte(t, '/')]+"/*":
+				n = 1
+			case mt == "*/*":
+				n = 0
+			}
+			if n > spec[i] {
+				qs[i], spec[i] = q, n
+			}
+		}
+	}
+
+	switch {
+	case qs[0] <= 0 && qs[1] <= 0:
+		return "", false
+	case qs[1] > qs[0]:
+		return types[1], true
+	}
+	return types[0], true
+}
@@ -0,0 +1,102 @@
+package gouuidv6
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIDHandler(t *testing.T) {
+
+	h := &IDHandler{MaxCount: 10}
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	w := get("/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d", w.Code)
+	}
+	if u, err := Parse(strings.TrimSpace(w.Body.String())); err != nil || !u.IsValid() {
+		t.Fatalf("Response is not a valid UUID (err=%v): %q", err, w.Body.String())
+	}
+
+	w = get("/?count=5&format=b64")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 UUIDs, got: %q", w.Body.String())
+	}
+	if _, err := ParseB64(lines[4]); err != nil {
+		t.Fatalf("Expected base64 UUID, got %q: %v", lines[4], err)
+	}
+
+	for _, url := range []string{"/?count=11", "/?count=0", "/?count=x", "/?format=b32"} {
+		if w := get(url); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", url, w.Code)
+		}
+	}
+
+	if w := get("/?type=xml"); w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 for type=xml, got %d", w.Code)
+	}
+
+	w = get("/?count=3&type=json")
+	var ids []string
+	if err := json.Unmarshal(w.Body.Bytes(), &ids); err != nil || len(ids) != 3 {
+		t.Fatalf("Expected JSON array of 3 UUIDs (err=%v): %q", err, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Expected JSON content type, got %q", ct)
+	}
+
+	for accept, want := range map[string]string{
+		"application/json":                   "application/json",
+		"text/html, application/json;q=0.9":  "application/json",
+		"application/json;q=0.5, text/plain": "text/plain; charset=utf-8",
+		"*/*":                                "text/plain; charset=utf-8",
+		"text/*;q=0.1, */*":                  "application/json",
+		"image/png":                          "",
+		"application/json;q=0, text/*;q=0":   "",
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if want == "" {
+			if w.Code != http.StatusNotAcceptable {
+				t.Fatalf("Accept %q: expected status 406, got %d", accept, w.Code)
+			}
+			continue
+		}
+		if ct := w.Header().Get("Content-Type"); ct != want {
+			t.Fatalf("Accept %q: expected %q, got %q", accept, want, ct)
+		}
+	}
+
+	// HEAD gives the headers without generating anything
+	g := NewGenerator()
+	hh := &IDHandler{Generator: g}
+	w = httptest.NewRecorder()
+	hh.ServeHTTP(w, httptest.NewRequest("HEAD", "/?count=5&type=json", nil))
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Fatalf("Expected status 200 and no body for HEAD, got %d and %q", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Expected JSON content type for HEAD, got %q", ct)
+	}
+	if n := g.Metrics().Generated; n != 0 {
+		t.Fatalf("Expected no UUIDs generated for HEAD, got %d", n)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected status 405 for POST, got %d", w.Code)
+	}
+
+}
@@ -0,0 +1,57 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"time"
+)
+
+// IdempotencyKey is a UUID sent by a client with a request (typically in an
+// Idempotency-Key header) so the server can recognise retries.  Since the
+// key holds the time it was created, a server that only remembers keys for
+// a dedupe window can reject keys older than the window with Expired,
+// without storing or looking up a time for each one.
+type IdempotencyKey UUID
+
+// NewIdempotencyKey returns a new key created with New.
+func NewIdempotencyKey() IdempotencyKey { return IdempotencyKey(New()) }
+
+// ParseIdempotencyKey parses a key in the standard hex or base64 form, as
+// BindParam does, so it must be a valid version 6 UUID.
+func ParseIdempotencyKey(s string) (IdempotencyKey, error) {
+	u, err := BindParam(s)
+	if err != nil {
+		return IdempotencyKey{}, fmt.Errorf("gouuidv6: invalid idempotency key %q: %v", s, err.(*BindError).Reason)
+	}
+	return IdempotencyKey(u), nil
+}
+
+// Age returns how long ago the key was created, by the clock of the default
+// Generator (see SetTimeFunc).
+func (k IdempotencyKey) Age() time.Duration { return defaultNow().Sub(UUID(k).Time()) }
+
+// IdempotencyKeySkew is how far in the future Expired allows a key's time to
+// be, for clients whose clocks are a little ahead of the server's.
+const IdempotencyKeySkew = time.Minute
+
+// Expired returns true if the key was created more than window ago, so a
+// server remembering keys for window can no longer tell if it is a retry and
+// should reject it.  Keys from more than IdempotencyKeySkew in the future are
+// expired too, as they would otherwise stay valid for longer than window.
+func (k IdempotencyKey) Expired(window time.Duration) bool {
+	return k.ExpiredSkew(window, IdempotencyKeySkew)
+}
+
+// ExpiredSkew is like Expired but allows keys up to skew in the future.
+func (k IdempotencyKey) ExpiredSkew(window, skew time.Duration) bool {
+	age := k.Age()
+	return age < -skew || age > window
+}
+
+func (k IdempotencyKey) String() string { return UUID(k).String() }
+
+func (k IdempotencyKey) MarshalText() ([]byte, error) { return UUID(k).MarshalText() }
+
+func (k *IdempotencyKey) UnmarshalText(text []byte) (err error) {
+	*k, err = ParseIdempotencyKey(string(text))
+	return
+}
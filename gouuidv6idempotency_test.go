@@ -0,0 +1,51 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	SetTimeFunc(func() time.Time { return now })
+	defer ResetTimeFunc()
+
+	k := NewIdempotencyKey()
+
+	now = now.Add(5 * time.Minute)
+	if k.Expired(10 * time.Minute) {
+		t.Fatalf("Expected key aged %v not to be expired", k.Age())
+	}
+	now = now.Add(6 * time.Minute)
+	if !k.Expired(10 * time.Minute) {
+		t.Fatalf("Expected key aged %v to be expired", k.Age())
+	}
+
+	// from a client with its clock ahead
+	k = NewIdempotencyKey()
+	now = now.Add(-30 * time.Second)
+	if k.Expired(10 * time.Minute) {
+		t.Fatalf("Expected key aged %v not to be expired", k.Age())
+	}
+	if !k.ExpiredSkew(10*time.Minute, 10*time.Second) {
+		t.Fatalf("Expected key aged %v to be expired with 10s of skew", k.Age())
+	}
+	now = now.Add(-time.Hour)
+	if !k.Expired(10 * time.Minute) {
+		t.Fatalf("Expected key aged %v to be expired", k.Age())
+	}
+
+	for _, s := range []string{k.String(), UUIDB64(k).String()} {
+		got, err := ParseIdempotencyKey(s)
+		if err != nil || got != k {
+			t.Fatalf("Expected %v from %q, got %v (err=%v)", k, s, got, err)
+		}
+	}
+
+	var k2 IdempotencyKey
+	if err := k2.UnmarshalText([]byte("7d444840-9dc0-11d1-b245-5ffdce74fad2")); err == nil {
+		t.Fatalf("Expected error for version 1 key")
+	}
+
+}
@@ -0,0 +1,111 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"time"
+)
+
+// Join returns a key for embedded key-value stores such as Bolt, Badger and
+// Pebble: prefix (a table or index name, a tenant ID and so on) followed by
+// the 16 bytes of u.  Keys with the same prefix sort by the time of their
+// UUIDs, since those stores order keys by their bytes.  prefix is not
+// modified.
+func Join(prefix []byte, u UUID) []byte {
+	ret := make([]byte, len(prefix)+16)
+	copy(ret, prefix)
+	copy(ret[len(prefix):], u[:])
+	return ret
+}
+
+// SplitKey is the inverse of Join, returning the prefix of key and the UUID
+// in its last 16 bytes.  The prefix shares memory with key.
+func SplitKey(key []byte) ([]byte, UUID, error) {
+	var u UUID
+	if len(key) < 16 {
+		return nil, u, fmt.Errorf("gouuidv6: key of %d bytes is too short for a UUID", len(key))
+	}
+	n := len(key) - 16
+	copy(u[:], key[n:])
+	return key[:n:n], u, nil
+}
+
+// KeyRange returns the bounds of the keys from Join with prefix and a UUID
+// time from start up to but not including end, for a range scan: lo is the
+// first key to seek to and every key in the range sorts before hi.  With
+// Bolt, for example:
+//
+//	lo, hi := gouuidv6.KeyRange(prefix, start, end)
+//	c := bucket.Cursor()
+//	for k, v := c.Seek(lo); k != nil && bytes.Compare(k, hi) < 0; k, v = c.Next() {
+//		...
+//	}
+//
+// Pebble and Badger take the same bounds as LowerBound and UpperBound
+// iterator options, or by seeking to lo and stopping at hi.
+func KeyRange(prefix []byte, start, end time.Time) (lo, hi []byte) {
+	return Join(prefix, FirstForTime(start)), Join(prefix, FirstForTime(end))
+}
+
+// TimePrefix returns prefix followed by the first n bytes (0 to 6) of the
+// UUIDs with the time t, for a prefix scan over every key from Join in a
+// fixed window of time around t.  The window is aligned to a multiple of its
+// own length, which is about 409.6µs for n = 6, 104.9ms for 5, 26.8s for 4,
+// 1.9 hours for 3, 20.4 days for 2 and 14.3 years for 1.  Use KeyRange for
+// windows of any other length.  n is clamped to 0 to 6.
+func TimePrefix(prefix []byte, t time.Time, n int) []byte {
+	if n < 0 {
+		n = 0
+	}
+	if n > 6 {
+		n = 6
+	}
+	u := FirstForTime(t)
+	ret := make([]byte, len(prefix)+n)
+	copy(ret, prefix)
+	copy(ret[len(prefix):], u[:n])
+	return ret
+}
+
+// IterBounds returns the bounds for an LSM iterator (Pebble, LevelDB and the
+// like) over keys that are bare UUIDs with times from from up to but not
+// including to.  lower is inclusive and upper exclusive, as Pebble's
+// IterOptions.LowerBound and UpperBound and LevelDB's util.Range Start and
+// Limit expect.  Use KeyRange for keys from Join, and IterBoundsInclusive to
+// include UUIDs with the time to.
+func IterBounds(from, to time.Time) (lower, upper []byte) {
+	return KeyRange(nil, from, to)
+}
+
+// IterBoundsInclusive is like IterBounds but includes the UUIDs with the time
+// to, as the iterator's exclusive upper bound is the key right after the
+// highest such UUID (see NextKey).
+func IterBoundsInclusive(from, to time.Time) (lower, upper []byte) {
+	last := LastForTime(to)
+	return Join(nil, FirstForTime(from)), NextKey(last[:])
+}
+
+// NextKey returns the key that sorts immediately after key, key with a zero
+// byte appended, since no key sorts between them.  Pass it as an exclusive
+// upper bound to include key, or as an inclusive lower bound to exclude key,
+// such as to resume a scan after the last key of the previous page.
+func NextKey(key []byte) []byte {
+	ret := make([]byte, len(key)+1)
+	copy(ret, key)
+	return ret
+}
+
+// PrefixEnd returns the exclusive upper bound of the keys starting with
+// prefix: the first key that sorts after all of them, for a prefix from
+// TimePrefix and the like.  It returns nil (no upper bound) if prefix is
+// empty or all 0xFF bytes.
+func PrefixEnd(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			ret := make([]byte, i+1)
+			copy(ret, prefix)
+			ret[i]++
+			return ret
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestKeyRange(t *testing.T) {
+
+	prefix := []byte("orders/")
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	g := NewGenerator()
+	var keys [][]byte
+	for i := 0; i < 10; i++ {
+		keys = append(keys, Join(prefix, g.NewFromTime(base.Add(time.Duration(i)*time.Second))))
+	}
+	// keys with other prefixes around them must stay out of the range
+	keys = append(keys, Join([]byte("orders."), g.NewFromTime(base)), Join([]byte("ordert/"), g.NewFromTime(base)))
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+
+	lo, hi := KeyRange(prefix, base.Add(3*time.Second), base.Add(7*time.Second))
+	var got []time.Time
+	for _, k := range keys {
+		if bytes.Compare(k, lo) >= 0 && bytes.Compare(k, hi) < 0 {
+			p, u, err := SplitKey(k)
+			if err != nil || !bytes.Equal(p, prefix) {
+				t.Fatalf("Expected key with prefix %q, got %q (%v)", prefix, p, err)
+			}
+			got = append(got, u.Time())
+		}
+	}
+	if len(got) != 4 || !got[0].Equal(base.Add(3*time.Second)) || !got[3].Equal(base.Add(6*time.Second)) {
+		t.Fatalf("Expected the 4 keys from 3s to 6s, got %v", got)
+	}
+}
+
+func TestJoinSplitKey(t *testing.T) {
+
+	u := New()
+	prefix := []byte("p")
+	k := Join(prefix, u)
+	p, u2, err := SplitKey(k)
+	if err != nil || u2 != u || string(p) != "p" {
+		t.Fatalf("Expected %q and %v, got %q and %v (%v)", prefix, u, p, u2, err)
+	}
+	_ = append(p, 'x')
+	if k[1] != u[0] {
+		t.Fatalf("Expected appending to the prefix to leave the key alone")
+	}
+	if _, _, err := SplitKey(k[:15]); err == nil {
+		t.Fatalf("Expected error for a 15 byte key")
+	}
+}
+
+func TestTimePrefix(t *testing.T) {
+
+	tm := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	u := NewFromTime(tm.Add(10 * time.Millisecond))
+	k := Join([]byte("x"), u)
+
+	if p := TimePrefix([]byte("x"), tm, 5); !bytes.HasPrefix(k, p) || len(p) != 6 {
+		t.Fatalf("Expected %x to have prefix %x", k, p)
+	}
+	if p := TimePrefix([]byte("x"), tm, 6); bytes.HasPrefix(k, p) {
+		t.Fatalf("Expected %x not to be in the 409.6µs window of %x", k, p)
+	}
+	if p := TimePrefix(nil, tm, 9); len(p) != 6 {
+		t.Fatalf("Expected n to be clamped to 6, got %d bytes", len(p))
+	}
+	if p := TimePrefix([]byte("x"), tm, -1); string(p) != "x" {
+		t.Fatalf("Expected n to be clamped to 0, got %q", p)
+	}
+}
+
+func TestIterBounds(t *testing.T) {
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator()
+	var keys [][]byte
+	for i := 0; i < 5; i++ {
+		tm := base.Add(time.Duration(i) * time.Second)
+		first, last := FirstForTime(tm), LastForTime(tm)
+		u := g.NewFromTime(tm)
+		keys = append(keys, first[:], u[:], last[:])
+	}
+
+	count := func(lower, upper []byte) int {
+		n := 0
+		for _, k := range keys {
+			if bytes.Compare(k, lower) >= 0 && (upper == nil || bytes.Compare(k, upper) < 0) {
+				n++
+			}
+		}
+		return n
+	}
+
+	from, to := base.Add(time.Second), base.Add(3*time.Second)
+	if n := count(IterBounds(from, to)); n != 6 {
+		t.Fatalf("Expected 6 keys from 1s up to 3s, got %d", n)
+	}
+	if n := count(IterBoundsInclusive(from, to)); n != 9 {
+		t.Fatalf("Expected 9 keys from 1s to 3s inclusive, got %d", n)
+	}
+
+	// resuming after a key skips exactly that key
+	lower, upper := IterBounds(from, to)
+	if n := count(NextKey(lower), upper); n != 5 {
+		t.Fatalf("Expected 5 keys after the first, got %d", n)
+	}
+
+	for _, c := range []struct{ in, want []byte }{
+		{[]byte("ab"), []byte("ac")},
+		{[]byte{1, 0xFF, 0xFF}, []byte{2}},
+		{[]byte{0xFF}, nil},
+		{nil, nil},
+	} {
+		if got := PrefixEnd(c.in); !bytes.Equal(got, c.want) {
+			t.Fatalf("Expected PrefixEnd(%x) to be %x, got %x", c.in, c.want, got)
+		}
+	}
+	p := TimePrefix(nil, base, 4)
+	if n := count(p, PrefixEnd(p)); n != 15 {
+		t.Fatalf("Expected all 15 keys in the 26.8s window, got %d", n)
+	}
+}
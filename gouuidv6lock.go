@@ -0,0 +1,64 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SlotLock is an exclusive claim on one clock sequence partition, held by
+// the calling process until Release is called or the process exits.  Use it
+// when several processes on the same host share a node (such as the MAC
+// address), so that each gets its own share of the clock sequence space:
+//
+//	lock, err := gouuidv6.LockSlot("/var/run/myapp", 16)
+//	if err != nil { ... }
+//	defer lock.Release()
+//	g := gouuidv6.NewGenerator(gouuidv6.WithNode(node), lock.Option())
+type SlotLock struct {
+	f     *os.File
+	slot  int
+	slots int
+}
+
+// LockSlot claims the first free slot of slots by taking an exclusive lock
+// on a file named gouuidv6-slot-N.lock in dir.  All processes sharing a node
+// must use the same dir and slots.  It returns an error if every slot is
+// already held by another process.  File locking is only available on unix.
+func LockSlot(dir string, slots int) (*SlotLock, error) {
+
+	if slots < 1 || slots > 0x4000 {
+		return nil, fmt.Errorf("gouuidv6: slots must be between 1 and 16384")
+	}
+
+	for slot := 0; slot < slots; slot++ {
+
+		f, err := os.OpenFile(filepath.Join(dir, fmt.Sprintf("gouuidv6-slot-%d.lock", slot)), os.O_RDWR|os.O_CREATE, 0666)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return &SlotLock{f: f, slot: slot, slots: slots}, nil
+		}
+		f.Close()
+	}
+
+	return nil, fmt.Errorf("gouuidv6: all %d slots in %s are in use", slots, dir)
+}
+
+// Return the slot number held.
+func (l *SlotLock) Slot() int { return l.slot }
+
+// Return a GeneratorOption restricting a Generator to the clock sequence
+// partition for the slot held (see WithClockSeqPartition).
+func (l *SlotLock) Option() GeneratorOption { return WithClockSeqPartition(l.slot, l.slots) }
+
+// Release the slot so another process can claim it.  Generators using the
+// slot must not be used afterwards.
+func (l *SlotLock) Release() error { return l.f.Close() }
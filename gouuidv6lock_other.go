@@ -0,0 +1,13 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package gouuidv6
+
+import (
+	"fmt"
+	"os"
+)
+
+func tryLockFile(f *os.File) (bool, error) {
+	return false, fmt.Errorf("gouuidv6: file locking is not supported on this platform")
+}
@@ -0,0 +1,62 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package gouuidv6
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestClockSeqPartition(t *testing.T) {
+
+	tim := time.Now()
+	g := NewGenerator(WithClockSeqPartition(3, 4), WithClockSeq(0x3fff))
+
+	for i := 0; i < 10000; i++ {
+		cs := g.NewFromTime(tim).ClockSeq()
+		if cs < 3*0x1000 || cs >= 4*0x1000 {
+			t.Fatalf("Clock sequence %d outside of partition 3 of 4", cs)
+		}
+	}
+
+}
+
+func TestLockSlot(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "gouuidv6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l1, err := LockSlot(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, err := LockSlot(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l1.Slot() != 0 || l2.Slot() != 1 {
+		t.Fatalf("Expected slots 0 and 1, got %d and %d", l1.Slot(), l2.Slot())
+	}
+
+	if _, err := LockSlot(dir, 2); err == nil {
+		t.Fatalf("Expected error with all slots in use")
+	}
+
+	l1.Release()
+	l3, err := LockSlot(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l3.Slot() != 0 {
+		t.Fatalf("Expected released slot 0 to be reused, got %d", l3.Slot())
+	}
+	l3.Release()
+	l2.Release()
+
+}
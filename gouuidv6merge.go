@@ -0,0 +1,71 @@
+package gouuidv6
+
+import "container/heap"
+
+// MergeSorted returns an iterator over the UUIDs from iters, each of which
+// must return UUIDs in sorted order (and false once it has no more), merged
+// into one sorted stream, for combining per-shard exports by time.
+// Equal UUIDs are all returned, those from earlier iters first.  Each of
+// iters is called only when its next UUID is needed, so they may be
+// arbitrarily long.
+func MergeSorted(iters ...func() (UUID, bool)) func() (UUID, bool) {
+
+	h := make(mergeHeap, 0, len(iters))
+	for i, it := range iters {
+		if u, ok := it(); ok {
+			h = append(h, mergeItem{u: u, i: i})
+		}
+	}
+	heap.Init(&h)
+
+	return func() (UUID, bool) {
+		if len(h) == 0 {
+			return UUID{}, false
+		}
+		ret := h[0].u
+		if u, ok := iters[h[0].i](); ok {
+			h[0].u = u
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+		return ret, true
+	}
+}
+
+// SliceIter returns an iterator over uuids, for use with MergeSorted.
+func SliceIter(uuids []UUID) func() (UUID, bool) {
+	return func() (UUID, bool) {
+		if len(uuids) == 0 {
+			return UUID{}, false
+		}
+		u := uuids[0]
+		uuids = uuids[1:]
+		return u, true
+	}
+}
+
+// next UUID from iterator i
+type mergeItem struct {
+	u UUID
+	i int
+}
+
+// mergeHeap is a heap.Interface with the lowest UUID first
+type mergeHeap []mergeItem
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(a, b int) bool {
+	if h[a].u == h[b].u {
+		return h[a].i < h[b].i
+	}
+	return less(h[a].u, h[b].u)
+}
+func (h mergeHeap) Swap(a, b int)       { h[a], h[b] = h[b], h[a] }
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(mergeItem)) }
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,48 @@
+package gouuidv6
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestMergeSorted(t *testing.T) {
+
+	all := NewBatchParallel(1000, 1)
+
+	// deal into 4 sorted shards of uneven size, one of them empty
+	shards := make([][]UUID, 4)
+	for i, u := range all {
+		s := i % 3
+		if i%7 == 0 {
+			s = 2
+		}
+		shards[s] = append(shards[s], u)
+	}
+	shards[1] = append(shards[1], all[500]) // a duplicate
+	sort.Slice(shards[1], func(i, j int) bool { return less(shards[1][i], shards[1][j]) })
+
+	var iters []func() (UUID, bool)
+	for _, s := range shards {
+		iters = append(iters, SliceIter(s))
+	}
+	next := MergeSorted(iters...)
+
+	var got []UUID
+	for u, ok := next(); ok; u, ok = next() {
+		got = append(got, u)
+	}
+	if len(got) != 1001 {
+		t.Fatalf("Expected 1001 UUIDs, got %d", len(got))
+	}
+	if !sort.SliceIsSorted(got, func(i, j int) bool { return less(got[i], got[j]) }) {
+		t.Fatalf("Expected merged UUIDs in order")
+	}
+	if _, ok := next(); ok {
+		t.Fatalf("Expected iterator to stay exhausted")
+	}
+
+	if _, ok := MergeSorted()(); ok {
+		t.Fatalf("Expected nothing from no iterators")
+	}
+
+}
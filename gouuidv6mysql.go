@@ -0,0 +1,78 @@
+package gouuidv6
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// MySQLUUID stores a UUID in a MySQL BINARY(16) column in either of the
+// layouts MySQL 8's UUID_TO_BIN and BIN_TO_UUID functions use, so rows can
+// be written and read by Go and by SQL alike.  With Swap false the bytes are
+// in the usual order, as UUID_TO_BIN(x) stores them and as UUID's own Value
+// and Scan use; for version 6 UUIDs this order already sorts by time, so it
+// is the layout to choose for new tables.  With Swap true they are in the
+// order of UUID_TO_BIN(x, 1), which moves the time-high field to the front
+// for version 1 UUIDs; applied to a version 6 UUID it puts the low time bits
+// first, so only use it for columns that SQL already fills that way.  Set
+// Swap before calling Scan.
+type MySQLUUID struct {
+	UUID UUID
+	Swap bool
+}
+
+// UUIDToBin returns the bytes of u as MySQL's UUID_TO_BIN(u, swap) does.
+func UUIDToBin(u UUID, swap bool) [16]byte {
+	if !swap {
+		return u
+	}
+	var ret [16]byte
+	copy(ret[0:2], u[6:8])
+	copy(ret[2:4], u[4:6])
+	copy(ret[4:8], u[0:4])
+	copy(ret[8:], u[8:])
+	return ret
+}
+
+// BinToUUID returns the UUID for bytes b as MySQL's BIN_TO_UUID(b, swap)
+// does, the inverse of UUIDToBin.
+func BinToUUID(b [16]byte, swap bool) UUID {
+	if !swap {
+		return b
+	}
+	var ret UUID
+	copy(ret[0:4], b[4:8])
+	copy(ret[4:6], b[2:4])
+	copy(ret[6:8], b[0:2])
+	copy(ret[8:], b[8:])
+	return ret
+}
+
+func (m MySQLUUID) Value() (driver.Value, error) {
+	b := UUIDToBin(m.UUID, m.Swap)
+	return b[:], nil
+}
+
+// Scan reads 16 bytes in the layout selected by Swap, or the text form of a
+// UUID, as a CHAR column or BIN_TO_UUID returns.
+func (m *MySQLUUID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 16 {
+			m.UUID = BinToUUID([16]byte(v), m.Swap)
+			return nil
+		}
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	}
+	return fmt.Errorf("gouuidv6: cannot convert from sql driver type %T to MySQLUUID", value)
+}
+
+func (m *MySQLUUID) scanString(s string) error {
+	u, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	m.UUID = u
+	return nil
+}
@@ -0,0 +1,51 @@
+package gouuidv6
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestMySQLUUID(t *testing.T) {
+
+	// the example from the MySQL manual for UUID_TO_BIN
+	u, err := Parse("6ccd780c-baba-1026-9564-5b8c656024db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		swap bool
+		want string
+	}{
+		{false, "6ccd780cbaba102695645b8c656024db"},
+		{true, "1026baba6ccd780c95645b8c656024db"},
+	} {
+		b := UUIDToBin(u, c.swap)
+		if got := hex.EncodeToString(b[:]); got != c.want {
+			t.Fatalf("Expected UUID_TO_BIN(x, %v) to be %s, got %s", c.swap, c.want, got)
+		}
+		if got := BinToUUID(b, c.swap); got != u {
+			t.Fatalf("Expected BIN_TO_UUID to give back %v, got %v", u, got)
+		}
+
+		v, err := MySQLUUID{UUID: u, Swap: c.swap}.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := MySQLUUID{Swap: c.swap}
+		if err := m.Scan(v); err != nil || m.UUID != u {
+			t.Fatalf("Expected Scan to give back %v, got %v (%v)", u, m.UUID, err)
+		}
+		m = MySQLUUID{Swap: c.swap}
+		if err := m.Scan(u.String()); err != nil || m.UUID != u {
+			t.Fatalf("Expected Scan of the text form to give %v, got %v (%v)", u, m.UUID, err)
+		}
+	}
+
+	var m MySQLUUID
+	if err := m.Scan(int64(1)); err == nil {
+		t.Fatalf("Expected error scanning an int64")
+	}
+	if err := m.Scan([]byte("short")); err == nil {
+		t.Fatalf("Expected error scanning 5 bytes")
+	}
+}
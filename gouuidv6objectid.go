@@ -0,0 +1,43 @@
+package gouuidv6
+
+import "fmt"
+
+// FromObjectID returns a version 6 UUID for the MongoDB ObjectID id, for
+// moving documents into UUID-keyed systems.  An ObjectID is a 4-byte Unix
+// time in seconds, 5 random bytes per process and a 3-byte counter.  The
+// UUID has the same second, with the top 10 bits of the counter as 100ns
+// intervals after it and the low 14 bits as the clock sequence, and a node of
+// 0x4D ('M', which has the multicast bit set) followed by the random bytes.
+// So UUIDs from one process's ObjectIDs sort in the same order as the
+// ObjectIDs, and map back with ToObjectID.
+func FromObjectID(id [12]byte) UUID {
+	secs := uint64(bigEnd.Uint32(id[:4]))
+	counter := uint64(id[9])<<16 | uint64(id[10])<<8 | uint64(id[11])
+	ret := FromGregorianTimestamp(tsoff+secs*10000000+counter>>14, uint16(counter), 0)
+	ret[10] = 0x4D
+	copy(ret[11:], id[4:9])
+	return ret
+}
+
+// ToObjectID returns the MongoDB ObjectID for a UUID from FromObjectID.
+// Other UUIDs are approximated: the time is truncated to the second, the
+// random bytes are the last 5 bytes of the node and the counter is made from
+// the clock sequence and the rest of the time.  It returns an error if the
+// UUID is not version 6 or its time does not fit in an ObjectID (1970 to
+// 2106).
+func (u UUID) ToObjectID() ([12]byte, error) {
+	var ret [12]byte
+	if !u.isV6() {
+		return ret, fmt.Errorf("gouuidv6: %v is not a version 6 UUID", u)
+	}
+	ts := u.timestamp()
+	if ts < tsoff || (ts-tsoff)/10000000 > 0xFFFFFFFF {
+		return ret, fmt.Errorf("gouuidv6: time of %v out of ObjectID range", u)
+	}
+	secs, sub := (ts-tsoff)/10000000, (ts-tsoff)%10000000
+	counter := (sub<<14 | uint64(u.ClockSeq())) & 0xFFFFFF
+	bigEnd.PutUint32(ret[:4], uint32(secs))
+	copy(ret[4:9], u[11:])
+	ret[9], ret[10], ret[11] = byte(counter>>16), byte(counter>>8), byte(counter)
+	return ret, nil
+}
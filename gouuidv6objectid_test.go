@@ -0,0 +1,43 @@
+package gouuidv6
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestObjectID(t *testing.T) {
+
+	var id [12]byte
+	hex.Decode(id[:], []byte("65937a45a1b2c3d4e5fedcba"))
+
+	u := FromObjectID(id)
+	if want := time.Unix(0x65937a45, 0); u.Time().Truncate(time.Second) != want {
+		t.Fatalf("Expected time %v, got %v", want, u.Time())
+	}
+	if !u.IsValid() || !u.IsRandomNode() {
+		t.Fatalf("Unexpected UUID %v from ObjectID", u)
+	}
+
+	got, err := u.ToObjectID()
+	if err != nil || got != id {
+		t.Fatalf("Expected %x back, got %x (err=%v)", id, got, err)
+	}
+
+	// order is preserved for one process
+	for _, i := range []int{11, 10, 9, 3} {
+		next := id
+		next[i]++
+		if !less(u, FromObjectID(next)) {
+			t.Fatalf("Expected %x to sort after %x", next, id)
+		}
+	}
+
+	if _, err := New().ToObjectID(); err != nil {
+		t.Fatalf("Expected any recent UUID to convert, got %v", err)
+	}
+	if _, err := NewFromTime(time.Unix(-1, 0)).ToObjectID(); err == nil {
+		t.Fatalf("Expected error for time before 1970")
+	}
+
+}
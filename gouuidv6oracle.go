@@ -0,0 +1,44 @@
+package gouuidv6
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// OracleUUID stores a UUID in an Oracle RAW(16) column.  The godror driver
+// (and the older ora drivers) bind and return RAW values as []byte, which
+// Value and Scan use, but Oracle gives RAW values as 32 upper case hex
+// digits wherever it converts them to text, such as with RAWTOHEX, SYS_GUID
+// selected into a VARCHAR2, or a driver configured to return strings, and
+// Scan accepts that and the other text forms Parse does too.
+type OracleUUID UUID
+
+func (u OracleUUID) Value() (driver.Value, error) {
+	return []byte(u[:]), nil
+}
+
+func (u *OracleUUID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		return u.scanString(string(v))
+	case string:
+		return u.scanString(v)
+	}
+	return fmt.Errorf("gouuidv6: cannot convert from sql driver type %T to OracleUUID", value)
+}
+
+func (u *OracleUUID) scanString(s string) error {
+	u2, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*u = OracleUUID(u2)
+	return nil
+}
+
+// Return the UUID in the usual text form.
+func (u OracleUUID) String() string { return UUID(u).String() }
@@ -0,0 +1,35 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOracleUUID(t *testing.T) {
+
+	u := New()
+
+	v, err := OracleUUID(u).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, u[:]) {
+		t.Fatalf("Expected Value to be the 16 bytes of %v, got %#v", u, v)
+	}
+
+	compact := strings.ToUpper(strings.ReplaceAll(u.String(), "-", ""))
+	for _, in := range []interface{}{v, compact, []byte(compact), u.String()} {
+		var o OracleUUID
+		if err := o.Scan(in); err != nil || UUID(o) != u {
+			t.Fatalf("Expected Scan of %#v to give %v, got %v (%v)", in, u, o, err)
+		}
+	}
+
+	var o OracleUUID
+	for _, in := range []interface{}{nil, int64(16), "not a uuid", []byte{1, 2, 3}} {
+		if err := o.Scan(in); err == nil {
+			t.Fatalf("Expected error scanning %#v", in)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package gouuidv6
+
+// Return the UUID as an OpenTelemetry trace ID.  The result converts directly
+// to trace.TraceID from go.opentelemetry.io/otel/trace, e.g.
+// trace.TraceID(u.TraceID()), so a v6 request ID can be propagated as trace
+// context.  Note that OpenTelemetry treats an all zero trace ID as invalid.
+func (u UUID) TraceID() [16]byte { return u }
+
+// Return an 8-byte OpenTelemetry span ID derived from the UUID, its high 8
+// bytes XORed with its low 8, so UUIDs from the same node (whose low bytes
+// only differ in the clock sequence) still get different span IDs.  The
+// result converts directly to trace.SpanID.
+func (u UUID) SpanID() [8]byte {
+	var ret [8]byte
+	bigEnd.PutUint64(ret[:], bigEnd.Uint64(u[:8])^bigEnd.Uint64(u[8:]))
+	return ret
+}
+
+// FromTraceID returns the UUID for an OpenTelemetry trace ID, the reverse of
+// TraceID.  The trace ID is only a valid version 6 UUID if it was created
+// from one.
+func FromTraceID(id [16]byte) UUID { return UUID(id) }
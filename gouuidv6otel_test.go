@@ -0,0 +1,28 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTraceID(t *testing.T) {
+
+	u, _ := Parse(`1ef200e7-8cb6-6000-8005-0000deadbeef`)
+
+	id := u.TraceID()
+	if FromTraceID(id) != u {
+		t.Fatalf("Round trip through trace ID changed %v to %v", u, FromTraceID(id))
+	}
+
+	if s := u.SpanID(); s != [8]byte{0x9e, 0xf7, 0x00, 0xe7, 0x52, 0x1b, 0xde, 0xef} {
+		t.Fatalf("Unexpected span ID %x", s)
+	}
+
+	// the time alone must give a different span ID
+	g := NewGenerator(WithClockSeq(5))
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if a, b := g.NewFromTime(tim), g.NewFromTime(tim.Add(time.Millisecond)); a.SpanID() == b.SpanID() {
+		t.Fatalf("Expected different span IDs for %v and %v", a, b)
+	}
+
+}
@@ -0,0 +1,24 @@
+package gouuidv6
+
+// Partition returns which of n partitions (0 to n-1) the UUID belongs to,
+// for keying Kafka topics and other partitioned stores by version 6 UUIDs.
+// It hashes the node, the clock sequence and the low 12 bits of the time,
+// which vary quickly, but not the rest of the time, so UUIDs created around
+// the same time are spread over all partitions rather than landing on one;
+// the node and clock sequence alone would put most of one Generator's UUIDs
+// on the same partition.  The result only depends on the UUID and n, so
+// every record with the same key goes to the same partition.  It panics if
+// n is less than 1.
+func (u UUID) Partition(n int) int {
+	if n < 1 {
+		panic("gouuidv6: Partition of less than 1 partition")
+	}
+	x := bigEnd.Uint64(u[8:]) ^ uint64(bigEnd.Uint16(u[6:8])&0x0FFF)<<50
+	// splitmix64 finalizer
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return int((x >> 32) * uint64(n) >> 32)
+}
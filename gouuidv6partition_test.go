@@ -0,0 +1,37 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartition(t *testing.T) {
+
+	u := New()
+	if u.Partition(12) != u.Partition(12) {
+		t.Fatalf("Expected the same partition for the same UUID")
+	}
+
+	// a burst from one generator should still spread evenly
+	g := NewGenerator(WithNode(0x123456789abc))
+	start := time.Now()
+	const n, count = 8, 80000
+	var counts [n]int
+	for i := 0; i < count; i++ {
+		p := g.NewFromTime(start.Add(time.Duration(i) * time.Microsecond)).Partition(n)
+		if p < 0 || p >= n {
+			t.Fatalf("Partition %d out of range", p)
+		}
+		counts[p]++
+	}
+	for p, c := range counts {
+		if c < count/n*8/10 || c > count/n*12/10 {
+			t.Fatalf("Expected about %d UUIDs in partition %d, got %d (%v)", count/n, p, c, counts)
+		}
+	}
+
+	if New().Partition(1) != 0 {
+		t.Fatalf("Expected partition 0 of 1")
+	}
+
+}
@@ -0,0 +1,68 @@
+package gouuidv6
+
+import (
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// PublicID returns an identifier for the UUID that can be shown outside the
+// system without revealing the time it was created or its node: the
+// HMAC-SHA-256 of the UUID with key, truncated to 128 bits and encoded with
+// Base64UUIDEncoding, so always 22 characters.  The same UUID and key always
+// give the same PublicID, so it can be stored alongside the UUID and looked
+// up, but it cannot be turned back into the UUID.
+func (u UUID) PublicID(key []byte) string {
+	h := hmac.New(sha256.New, key)
+	h.Write(u[:])
+	var sum [sha256.Size]byte
+	return Base64UUIDEncoding.EncodeToString(h.Sum(sum[:0])[:16])
+}
+
+// Encrypt returns the UUID encrypted with AES using key (which must be 16, 24
+// or 32 bytes long), for handing out opaque IDs that can be mapped back to
+// the original with Decrypt, without a lookup table.  A UUID is exactly one
+// AES block so the result is still 16 bytes, but the time, node and even the
+// version and variant bits are hidden: the result is not (except by chance)
+// a valid version 6 UUID.
+func (u UUID) Encrypt(key []byte) (UUID, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return UUID{}, err
+	}
+	var ret UUID
+	c.Encrypt(ret[:], u[:])
+	return ret, nil
+}
+
+// Decrypt reverses Encrypt with the same key.
+func (u UUID) Decrypt(key []byte) (UUID, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return UUID{}, err
+	}
+	var ret UUID
+	c.Decrypt(ret[:], u[:])
+	return ret, nil
+}
+
+// EqualConstantTime reports whether a and b are equal, taking the same time
+// whatever their contents, for comparing UUIDs used as secrets (such as
+// bearer tokens) without leaking timing information.
+func EqualConstantTime(a, b UUID) bool { return subtle.ConstantTimeCompare(a[:], b[:]) == 1 }
+
+// Redacted returns the text form of the UUID with the clock sequence and node
+// replaced by x's, e.g. "1ec0450e-5a64-6ca0-xxxx-xxxxxxxxxxxx", so IDs can be
+// logged to outside systems with their time but without the identity of the
+// host that created them.
+func (u UUID) Redacted() string {
+	var b [36]byte
+	encodeHex(b[:], u)
+	for i := 19; i < len(b); i++ {
+		if b[i] != '-' {
+			b[i] = 'x'
+		}
+	}
+	return string(b[:])
+}
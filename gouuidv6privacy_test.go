@@ -0,0 +1,73 @@
+package gouuidv6
+
+import "testing"
+
+func TestPublicID(t *testing.T) {
+
+	u, _ := Parse(`1ef200e7-8cb6-6000-8005-0000deadbeef`)
+	key := []byte("secret")
+
+	id := u.PublicID(key)
+	if len(id) != 22 {
+		t.Fatalf("Expected 22 character public ID, got %q", id)
+	}
+	if id != u.PublicID(key) {
+		t.Fatalf("Expected the same public ID every time")
+	}
+	if id == u.PublicID([]byte("other")) {
+		t.Fatalf("Expected a different public ID with a different key")
+	}
+	if u2, _ := Parse(`1ef200e7-8cb6-6000-8006-0000deadbeef`); u2.PublicID(key) == id {
+		t.Fatalf("Expected a different public ID for a different UUID")
+	}
+
+}
+
+func TestEncrypt(t *testing.T) {
+
+	u := New()
+	key := []byte("0123456789abcdef")
+
+	e, err := u.Encrypt(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e == u {
+		t.Fatalf("Expected encrypted UUID to differ")
+	}
+	d, err := e.Decrypt(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != u {
+		t.Fatalf("Expected %v back from Decrypt, got %v", u, d)
+	}
+
+	if _, err := u.Encrypt([]byte("short")); err == nil {
+		t.Fatalf("Expected error for invalid key length")
+	}
+
+}
+
+func TestEqualConstantTime(t *testing.T) {
+
+	u := New()
+	u2 := u
+	if !EqualConstantTime(u, u2) {
+		t.Fatalf("Expected %v to equal itself", u)
+	}
+	u2[15] ^= 1
+	if EqualConstantTime(u, u2) {
+		t.Fatalf("Expected %v not to equal %v", u, u2)
+	}
+
+}
+
+func TestRedacted(t *testing.T) {
+
+	u, _ := Parse(`1ec0450e-5a64-6ca0-8005-0000deadbeef`)
+	if s := u.Redacted(); s != `1ec0450e-5a64-6ca0-xxxx-xxxxxxxxxxxx` {
+		t.Fatalf("Unexpected redacted form %s", s)
+	}
+
+}
@@ -0,0 +1,38 @@
+package gouuidv6
+
+import (
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// Generate returns a random valid version 6 UUID, implementing
+// testing/quick.Generator so property-based tests get realistic UUIDs
+// rather than 16 random bytes.  Times are spread between 1970 and 2025, the
+// clock sequence is random, and the node is random with the multicast bit
+// set or, a quarter of the time, a MAC address from a small set so that some
+// UUIDs share a node.  The UUIDs only depend on rand, so a seed reproduces
+// them.  size is not used.
+func (UUID) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomUUID(rand))
+}
+
+// Generate returns a random valid UUIDB64 as UUID.Generate does.
+func (UUIDB64) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(UUIDB64(randomUUID(rand)))
+}
+
+// Latest time for Generate, in 100ns ticks since 1970.  It is fixed rather
+// than the current time so that a seed always gives the same UUIDs, and in
+// the past so they stay valid (see IsValid).
+var quickMaxTicks = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano() / 100
+
+// Return a random valid UUID for Generate.
+func randomUUID(r *rand.Rand) UUID {
+	ts := tsoff + uint64(r.Int63n(quickMaxTicks))
+	node := r.Uint64()&0x0000FFFFFFFFFFFF | 0x0000010000000000
+	if r.Intn(4) == 0 {
+		node = 0x00163e000000 | uint64(r.Intn(8))
+	}
+	return FromGregorianTimestamp(ts, uint16(r.Intn(0x4000)), node)
+}
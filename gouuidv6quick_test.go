@@ -0,0 +1,34 @@
+package gouuidv6
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestQuickGenerate(t *testing.T) {
+
+	valid := func(u UUID, b UUIDB64) bool { return u.IsValid() && UUID(b).IsValid() }
+	if err := quick.Check(valid, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// a property over generated UUIDs: the text form round trips
+	roundTrip := func(u UUID) bool {
+		v, err := Parse(u.String())
+		return err == nil && v == u
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the same seed gives the same UUIDs
+	r1, r2 := rand.New(rand.NewSource(1)), rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		var u UUID
+		if a, b := u.Generate(r1, 0).Interface(), u.Generate(r2, 0).Interface(); a != b {
+			t.Fatalf("Expected the same UUID from the same seed at %d, got %v and %v", i, a, b)
+		}
+	}
+
+}
@@ -0,0 +1,55 @@
+package gouuidv6
+
+import (
+	"crypto/rand"
+	"io"
+	mrand "math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// the source of random data set with SetRandReader, nil for crypto/rand
+var randReader atomic.Pointer[io.Reader]
+
+// SetRandReader sets where the package gets random data from, for seeding
+// clock sequences, random nodes and WithPrecision timestamps, instead of
+// crypto/rand.  This allows a hardware RNG or FIPS module to be used, or a
+// fixed reader in tests.  Pass nil to go back to crypto/rand.
+func SetRandReader(r io.Reader) {
+	if r == nil {
+		randReader.Store(nil)
+		return
+	}
+	randReader.Store(&r)
+}
+
+// Return 8 bytes of random data as a uint64.  If the random data cannot be
+// read the error is returned along with a value from the fallback source.
+func randUint64() (uint64, error) {
+	var v uint64
+	var err error
+	if r := randReader.Load(); r != nil {
+		b := make([]byte, 8)
+		_, err = io.ReadFull(*r, b)
+		v = bigEnd.Uint64(b)
+	} else {
+		// kept separate from the above so it does not escape to the heap
+		var b [8]byte
+		_, err = rand.Read(b[:])
+		v = bigEnd.Uint64(b[:])
+	}
+	if err != nil {
+		fallbackMu.Lock()
+		v = fallbackRand.Uint64()
+		fallbackMu.Unlock()
+	}
+	return v, err
+}
+
+// time-seeded source used when random data cannot be read, which is much
+// better than the zeros that would be used otherwise but not unpredictable
+var (
+	fallbackMu   sync.Mutex
+	fallbackRand = mrand.New(mrand.NewSource(time.Now().UnixNano()))
+)
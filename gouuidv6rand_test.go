@@ -0,0 +1,32 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetRandReader(t *testing.T) {
+
+	defer SetRandReader(nil)
+
+	SetRandReader(bytes.NewReader(bytes.Repeat([]byte{0}, 16)))
+	u := NewGenerator().New()
+	if u.ClockSeq() != 0 || u.Node().String() != "01:00:00:00:00:00" {
+		t.Fatalf("Expected zero clock sequence and node from zero reader, got %v", u)
+	}
+
+	// reader is now empty
+	g := NewGenerator()
+	if n := g.Metrics().EntropyFailures; n != 2 {
+		t.Fatalf("Expected 2 entropy failures, got %d", n)
+	}
+	if err := g.Healthz(); err == nil {
+		t.Fatalf("Expected Healthz to report the failing reader")
+	}
+
+	SetRandReader(nil)
+	if err := g.Healthz(); err != nil {
+		t.Fatalf("Expected Healthz to pass with crypto/rand: %v", err)
+	}
+
+}
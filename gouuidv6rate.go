@@ -0,0 +1,116 @@
+package gouuidv6
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// number of slots the window of a RateEstimator is divided into
+const rateSlots = 16
+
+// weight of each new interval in the moving average of a RateEstimator
+const rateAlpha = 1.0 / 32
+
+// RateEstimator measures how fast UUIDs are being generated from a stream of
+// them (for instance read from a Kafka topic), for capacity monitoring.  Like
+// DupDetector it uses only the times embedded in the UUIDs, not the local
+// clock, so it gives the same answers when replaying old data.  A
+// RateEstimator is safe for concurrent use.
+type RateEstimator struct {
+	mu       sync.Mutex
+	slotSize int64 // ticks per slot
+	counts   [rateSlots]uint64
+	slots    [rateSlots]int64 // slot number each of counts is for
+	maxSlot  int64            // slot of the newest UUID seen
+	latest   uint64           // timestamp of the newest UUID seen
+	total    uint64
+	mean     float64 // moving average of intervals, in ticks
+	variance float64 // moving variance of intervals
+}
+
+// RateStats is a snapshot of a RateEstimator.
+type RateStats struct {
+	Count    uint64        // UUIDs added in total
+	Latest   time.Time     // time of the newest UUID added
+	Rate     float64       // UUIDs per second over the window ending at Latest
+	Interval time.Duration // moving average of the interval between UUIDs
+	Jitter   time.Duration // moving standard deviation of the interval
+}
+
+// Return a new RateEstimator measuring the rate over window.
+func NewRateEstimator(window time.Duration) *RateEstimator {
+	slot := int64(window/100) / rateSlots
+	if slot < 1 {
+		slot = 1
+	}
+	e := &RateEstimator{slotSize: slot}
+	for i := range e.slots {
+		e.slots[i] = -1
+	}
+	return e
+}
+
+// Add records u.  UUIDs that are not version 6 are ignored.  UUIDs may
+// arrive somewhat out of order: those older than the window relative to the
+// newest UUID still count towards Count but not Rate, and only UUIDs at least
+// as new as the newest so far contribute an interval.
+func (e *RateEstimator) Add(u UUID) {
+
+	if !u.isV6() {
+		return
+	}
+
+	ts := u.timestamp()
+	slot := int64(ts) / e.slotSize
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.total > 0 && ts >= e.latest {
+		d := float64(ts-e.latest) - e.mean
+		e.mean += rateAlpha * d
+		e.variance = (1 - rateAlpha) * (e.variance + rateAlpha*d*d)
+	}
+	if e.total == 0 || ts > e.latest {
+		e.latest = ts
+	}
+	e.total++
+
+	if slot > e.maxSlot {
+		e.maxSlot = slot
+	}
+	if slot <= e.maxSlot-rateSlots {
+		return
+	}
+	i := slot % rateSlots
+	if e.slots[i] != slot {
+		e.slots[i], e.counts[i] = slot, 0
+	}
+	e.counts[i]++
+}
+
+// Return the current statistics.
+func (e *RateEstimator) Stats() RateStats {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var s RateStats
+	s.Count = e.total
+	if e.total == 0 {
+		return s
+	}
+	s.Latest = tsToTime(e.latest)
+
+	var n uint64
+	for i, slot := range e.slots {
+		if slot > e.maxSlot-rateSlots {
+			n += e.counts[i]
+		}
+	}
+	s.Rate = float64(n) / (float64(e.slotSize*rateSlots) / 1e7)
+	s.Interval = time.Duration(e.mean * 100)
+	s.Jitter = time.Duration(math.Sqrt(e.variance) * 100)
+	return s
+}
@@ -0,0 +1,50 @@
+package gouuidv6
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateEstimator(t *testing.T) {
+
+	e := NewRateEstimator(time.Second)
+	if s := e.Stats(); s.Count != 0 || s.Rate != 0 {
+		t.Fatalf("Expected empty stats, got %+v", s)
+	}
+
+	g := NewGenerator()
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// 500 per second for 3 seconds
+	for i := 0; i < 1500; i++ {
+		e.Add(g.NewFromTime(start.Add(time.Duration(i) * 2 * time.Millisecond)))
+	}
+
+	s := e.Stats()
+	if s.Count != 1500 {
+		t.Fatalf("Expected 1500 UUIDs, got %d", s.Count)
+	}
+	if math.Abs(s.Rate-500) > 500/rateSlots {
+		t.Fatalf("Expected rate of about 500/s, got %v", s.Rate)
+	}
+	if s.Interval < 1900*time.Microsecond || s.Interval > 2100*time.Microsecond || s.Jitter > 100*time.Microsecond {
+		t.Fatalf("Expected intervals of about 2ms, got %v (jitter %v)", s.Interval, s.Jitter)
+	}
+	if want := start.Add(2998 * time.Millisecond); !s.Latest.Equal(want) {
+		t.Fatalf("Expected latest %v, got %v", want, s.Latest)
+	}
+
+	// late arrivals outside the window don't count towards the rate
+	e.Add(g.NewFromTime(start))
+	if s2 := e.Stats(); s2.Count != 1501 || s2.Rate != s.Rate || !s2.Latest.Equal(s.Latest) {
+		t.Fatalf("Expected only the count to change, got %+v then %+v", s, s2)
+	}
+
+	// a gap clears old slots
+	e.Add(g.NewFromTime(start.Add(time.Minute)))
+	if s := e.Stats(); s.Rate != 1 {
+		t.Fatalf("Expected rate of 1/s after a gap, got %v", s.Rate)
+	}
+
+}
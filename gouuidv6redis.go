@@ -0,0 +1,47 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"time"
+)
+
+// Score returns the time of the UUID as a Redis sorted set score: the
+// number of milliseconds since the Unix epoch, with the fraction of a
+// millisecond after the decimal point, so members added with ZADD are
+// ordered by the time their UUIDs were created.  A float64 cannot hold
+// every 100ns tick of a current time, so the score is rounded to a multiple
+// of 2^-12 ms (about 244ns) for times before 2039 and 2^-11 ms (about
+// 488ns) after; UUIDs that close together get the same score, and Redis
+// then orders them by member, which keeps them in UUID order if the members
+// are from Member.  UnixMilli gives an exact score truncated to the
+// millisecond instead.  Returns 0 if the UUID is not version 6 or its time
+// is before 1970.
+func (u UUID) Score() float64 { return float64(u.Timestamp100ns()) / 10000 }
+
+// TimeScore returns the score of the UUIDs with the time t, for the bounds
+// of ZRANGE ... BYSCORE and ZREMRANGEBYSCORE.
+func TimeScore(t time.Time) float64 { return FirstForTime(t).Score() }
+
+// Member returns the UUID as a Redis sorted set member: the 22 character URL
+// safe base64 form from UUIDB64, which sorts in the same order as the UUID,
+// so members with equal scores are ordered by time and clock sequence.
+func (u UUID) Member() string { return UUIDB64(u).String() }
+
+// ParseMember returns the UUID for a sorted set member from Member, or one
+// stored as the 16 raw bytes or the usual text form.
+func ParseMember(s string) (UUID, error) {
+	switch len(s) {
+	case 16:
+		var u UUID
+		copy(u[:], s)
+		return u, nil
+	case 22:
+		u, err := ParseB64(s)
+		return UUID(u), err
+	}
+	u, err := Parse(s)
+	if err != nil {
+		return u, fmt.Errorf("gouuidv6: invalid sorted set member %q", s)
+	}
+	return u, nil
+}
@@ -0,0 +1,53 @@
+package gouuidv6
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+
+	tm := time.Date(2024, 6, 1, 12, 0, 0, 123456700, time.UTC)
+	u := NewFromTime(tm)
+	if s, want := u.Score(), float64(tm.UnixNano())/1e6; s < want-0.0004 || s > want+0.0004 {
+		t.Fatalf("Expected score %f, got %f", want, s)
+	}
+	if s := TimeScore(tm); s != u.Score() {
+		t.Fatalf("Expected TimeScore %f to equal the UUID's score %f", s, u.Score())
+	}
+	if s := (UUID{}).Score(); s != 0 {
+		t.Fatalf("Expected 0 for a nil UUID, got %f", s)
+	}
+
+	// members with equal scores sort by UUID, as Redis does with ties
+	g := NewGenerator()
+	var uuids []UUID
+	for i := 0; i < 100; i++ {
+		uuids = append(uuids, g.NewFromTime(tm))
+	}
+	members := make([]string, len(uuids))
+	for i, u := range uuids {
+		members[i] = u.Member()
+	}
+	sort.Strings(members)
+	for i, m := range members {
+		u, err := ParseMember(m)
+		if err != nil || u != uuids[i] {
+			t.Fatalf("Expected member %d to be %v, got %v (%v)", i, uuids[i], u, err)
+		}
+	}
+}
+
+func TestParseMember(t *testing.T) {
+
+	u := New()
+	for _, s := range []string{u.Member(), string(u[:]), u.String()} {
+		if got, err := ParseMember(s); err != nil || got != u {
+			t.Fatalf("Expected %v from member %q, got %v (%v)", u, s, got, err)
+		}
+	}
+	if _, err := ParseMember("nope"); err == nil {
+		t.Fatalf("Expected error for an invalid member")
+	}
+}
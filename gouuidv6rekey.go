@@ -0,0 +1,30 @@
+package gouuidv6
+
+import (
+	"crypto/sha1"
+	"time"
+)
+
+// Rekey returns the version 6 UUID that replaces the existing ID old (often
+// a random version 4 UUID) when re-keying a table, with the time createdAt,
+// usually from the row's created_at column.  The clock sequence and node are
+// taken from a SHA-1 hash of namespace and old, as version 5 UUIDs are, so
+// the same inputs always give the same new ID: batches can be re-run, and
+// foreign keys in other tables can be mapped independently without a shared
+// lookup table.  Use a different namespace for each project (or the zero
+// UUID) to keep their mappings apart.  The node has the multicast bit set,
+// so new IDs cannot collide with UUIDs from a real MAC address, and two old
+// IDs only map to the same new ID if their 61 hash bits (47 in the node and
+// 14 in the clock sequence) match as well as their createdAt, which is
+// negligible.  Rows with the same createdAt (such as when it only has whole
+// seconds) sort by their hashes rather than the order they were created in.
+func Rekey(namespace, old UUID, createdAt time.Time) UUID {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write(old[:])
+	var sum [sha1.Size]byte
+	h.Sum(sum[:0])
+	// the node is the low 48 bits (bytes 2 to 7) with the multicast bit set
+	node := bigEnd.Uint64(sum[:8]) | 0x010000000000
+	return FromGregorianTimestamp(tstime(createdAt), bigEnd.Uint16(sum[8:10]), node)
+}
@@ -0,0 +1,42 @@
+package gouuidv6
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRekey(t *testing.T) {
+
+	ns, err := Parse("1eea838b-4cc8-6000-8000-010000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := Parse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	if err != nil {
+		t.Fatal(err)
+	}
+	createdAt := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	u := Rekey(ns, old, createdAt)
+	if !u.IsValid() || !u.Time().Equal(createdAt) || !u.IsRandomNode() {
+		t.Fatalf("Expected a valid UUID with time %v and a multicast node, got %v", createdAt, u)
+	}
+	if u2 := Rekey(ns, old, createdAt); u2 != u {
+		t.Fatalf("Expected the same new ID every time, got %v and %v", u, u2)
+	}
+	if u2 := Rekey(UUID{}, old, createdAt); u2 == u {
+		t.Fatalf("Expected a different new ID in another namespace")
+	}
+
+	// old IDs created in the same second map to distinct new IDs
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[UUID]bool)
+	for i := 0; i < 10000; i++ {
+		u := Rekey(ns, randomUUID(r), createdAt)
+		if seen[u] {
+			t.Fatalf("Expected distinct new IDs, got %v twice", u)
+		}
+		seen[u] = true
+	}
+}
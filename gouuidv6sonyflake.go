@@ -0,0 +1,48 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"time"
+)
+
+// SonyflakeEpoch is the default start time of Sonyflake IDs, used by
+// FromSonyflake and ToSonyflake when they are given the zero time.
+var SonyflakeEpoch = time.Date(2014, 9, 1, 0, 0, 0, 0, time.UTC)
+
+// node of UUIDs from FromSonyflake, "SF" with the machine ID in the low 16
+// bits; 0x53 has the multicast bit set
+const sonyflakeNode = uint64(0x534600000000)
+
+// FromSonyflake returns a version 6 UUID for the Sonyflake ID id, whose
+// time is counted from start (SonyflakeEpoch if zero), so services using
+// Sonyflake can share a keyspace with UUIDs.  The Sonyflake layout is 39 bits
+// of time in 10ms units, an 8-bit sequence and a 16-bit machine ID; the UUID
+// has the same time, the sequence as its clock sequence and a node made from
+// the machine ID (with the multicast bit set), so UUIDs from Sonyflake IDs
+// sort in the same order as the IDs and map back with ToSonyflake.
+func FromSonyflake(id uint64, start time.Time) UUID {
+	if start.IsZero() {
+		start = SonyflakeEpoch
+	}
+	t := start.Add(time.Duration(id>>24&0x7FFFFFFFFF) * 10 * time.Millisecond)
+	return FromGregorianTimestamp(tstime(t), uint16(id>>16&0xFF), sonyflakeNode|(id&0xFFFF))
+}
+
+// ToSonyflake returns the Sonyflake ID with time counted from start
+// (SonyflakeEpoch if zero) for a UUID from FromSonyflake.  Other UUIDs are
+// approximated: the time is truncated to 10ms, and the sequence and machine
+// ID are the low bits of the clock sequence and node.  It returns an error
+// if the UUID is not version 6 or its time does not fit in a Sonyflake ID.
+func (u UUID) ToSonyflake(start time.Time) (uint64, error) {
+	if start.IsZero() {
+		start = SonyflakeEpoch
+	}
+	if !u.isV6() {
+		return 0, fmt.Errorf("gouuidv6: %v is not a version 6 UUID", u)
+	}
+	ts, sts := u.timestamp(), tstime(start)
+	if ts < sts || (ts-sts)/100000 > 0x7FFFFFFFFF {
+		return 0, fmt.Errorf("gouuidv6: time of %v out of Sonyflake range from %v", u, start)
+	}
+	return (ts-sts)/100000<<24 | uint64(u.ClockSeq()&0xFF)<<16 | uint64(bigEnd.Uint16(u[14:])), nil
+}
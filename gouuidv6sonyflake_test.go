@@ -0,0 +1,40 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSonyflake(t *testing.T) {
+
+	// 2024-01-02 03:04:05.67 UTC, sequence 9, machine 0xbeef
+	id := uint64(29463504567)<<24 | 9<<16 | 0xbeef
+
+	u := FromSonyflake(id, time.Time{})
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 670000000, time.UTC); !u.Time().Equal(want) {
+		t.Fatalf("Expected time %v, got %v", want, u.Time().UTC())
+	}
+	if !u.IsValid() || u.ClockSeq() != 9 || !u.IsRandomNode() {
+		t.Fatalf("Unexpected UUID %v from Sonyflake ID", u)
+	}
+
+	got, err := u.ToSonyflake(time.Time{})
+	if err != nil || got != id {
+		t.Fatalf("Expected %x back, got %x (err=%v)", id, got, err)
+	}
+
+	// order is preserved
+	if !less(u, FromSonyflake(id+1, time.Time{})) || !less(u, FromSonyflake(id+1<<16, time.Time{})) || !less(u, FromSonyflake(id+1<<24, time.Time{})) {
+		t.Fatalf("Expected UUIDs to sort in Sonyflake order")
+	}
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got, _ := FromSonyflake(id, start).ToSonyflake(start); got != id {
+		t.Fatalf("Expected %x back with custom start, got %x", id, got)
+	}
+
+	if _, err := NewFromTime(SonyflakeEpoch.Add(-time.Second)).ToSonyflake(time.Time{}); err == nil {
+		t.Fatalf("Expected error for time before the epoch")
+	}
+
+}
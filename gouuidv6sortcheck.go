@@ -0,0 +1,53 @@
+package gouuidv6
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// VerifySortOrder checks that encode, a text encoding of UUIDs, sorts the
+// same as the raw bytes, as String and UUIDB64's String do, returning an
+// error for the first pair of UUIDs it finds in the wrong order.  It tries
+// every pair of adjacent values of each byte, with the following bytes all
+// zero, all ones and carrying from one to the other, which exercises every
+// boundary between output characters for encodings that work a few bits at a
+// time such as hex, base32 and base64.
+func VerifySortOrder(encode func(u UUID) string) error {
+
+	fills := [][2]byte{{0x00, 0x00}, {0xFF, 0xFF}, {0xFF, 0x00}, {0x5A, 0xA5}}
+
+	for pos := 0; pos < 16; pos++ {
+		for _, fill := range fills {
+			for v := 0; v < 255; v++ {
+				var a, b UUID
+				for i := range a {
+					a[i], b[i] = 0x5A, 0x5A
+				}
+				a[pos], b[pos] = byte(v), byte(v+1)
+				for i := pos + 1; i < 16; i++ {
+					a[i], b[i] = fill[0], fill[1]
+				}
+				if ea, eb := encode(a), encode(b); ea >= eb {
+					return fmt.Errorf("gouuidv6: %x encodes as %q, which does not sort before %q for %x", a[:], ea, eb, b[:])
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// VerifyBase64Alphabet checks that UUIDs encoded in base64 with alphabet,
+// without padding, sort the same as the raw bytes, for anyone defining their
+// own variant of Base64UUIDAlphabet.  Such an alphabet must be in ascending
+// byte order.
+func VerifyBase64Alphabet(alphabet string) (err error) {
+	defer func() {
+		// NewEncoding panics on alphabets of the wrong length and the like
+		if r := recover(); r != nil {
+			err = fmt.Errorf("gouuidv6: invalid base64 alphabet: %v", r)
+		}
+	}()
+	enc := base64.NewEncoding(alphabet).WithPadding(base64.NoPadding)
+	return VerifySortOrder(func(u UUID) string { return enc.EncodeToString(u[:]) })
+}
@@ -0,0 +1,33 @@
+package gouuidv6
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestVerifySortOrder(t *testing.T) {
+
+	// the built-in encodings
+	if err := VerifySortOrder(UUID.String); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySortOrder(func(u UUID) string { return UUIDB64(u).String() }); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyBase64Alphabet(Base64UUIDAlphabet); err != nil {
+		t.Fatal(err)
+	}
+
+	// standard base64 does not sort
+	if err := VerifySortOrder(func(u UUID) string { return base64.RawURLEncoding.EncodeToString(u[:]) }); err == nil {
+		t.Fatalf("Expected URL base64 not to sort as raw bytes")
+	}
+	if err := VerifyBase64Alphabet(strings.Replace(Base64UUIDAlphabet, "AB", "BA", 1)); err == nil {
+		t.Fatalf("Expected an out of order alphabet to fail")
+	}
+	if err := VerifyBase64Alphabet("abc"); err == nil {
+		t.Fatalf("Expected a short alphabet to fail")
+	}
+
+}
@@ -0,0 +1,25 @@
+package gouuidv6
+
+import "time"
+
+// IDSource is what most code needs from a Generator, so it can depend on
+// this interface and have tests inject a fake (such as one returning a fixed
+// sequence) without touching the package's default Generator.  *Generator
+// implements it, and Default returns the package-level one.
+type IDSource interface {
+	New() UUID
+	NewFromTime(t time.Time) UUID
+}
+
+var _ IDSource = (*Generator)(nil)
+
+// Default returns an IDSource using the default Generator, as New and
+// NewFromTime do.
+func Default() IDSource { return defaultSource{} }
+
+// defaultSource calls the package-level functions, so it follows any changes
+// to the default Generator's configuration.
+type defaultSource struct{}
+
+func (defaultSource) New() UUID                    { return New() }
+func (defaultSource) NewFromTime(t time.Time) UUID { return NewFromTime(t) }
@@ -0,0 +1,33 @@
+package gouuidv6
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedSource is the kind of fake IDSource tests inject
+type fixedSource struct{ u UUID }
+
+func (s fixedSource) New() UUID                    { return s.u }
+func (s fixedSource) NewFromTime(t time.Time) UUID { return s.u }
+
+func TestIDSource(t *testing.T) {
+
+	newOrderID := func(ids IDSource) UUID { return ids.New() }
+
+	want := New()
+	if got := newOrderID(fixedSource{want}); got != want {
+		t.Fatalf("Expected fake ID %v, got %v", want, got)
+	}
+
+	for _, src := range []IDSource{Default(), NewGenerator()} {
+		if u := newOrderID(src); !u.IsValid() || u == want {
+			t.Fatalf("Expected a new valid UUID from %T, got %v", src, u)
+		}
+		tm := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		if u := src.NewFromTime(tm); !u.Time().Equal(tm) {
+			t.Fatalf("Expected time %v from %T, got %v", tm, src, u.Time())
+		}
+	}
+
+}
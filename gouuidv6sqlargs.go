@@ -0,0 +1,78 @@
+package gouuidv6
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect is the placeholder syntax of a SQL driver, for Placeholders.
+type Dialect int
+
+const (
+	// DialectQuestion is "?", for MySQL and SQLite.
+	DialectQuestion Dialect = iota
+	// DialectDollar is "$1", "$2" and so on, for Postgres.
+	DialectDollar
+	// DialectColon is ":1", ":2" and so on, for Oracle.
+	DialectColon
+	// DialectAtP is "@p1", "@p2" and so on, for SQL Server.
+	DialectAtP
+)
+
+// Args returns ids as arguments for a query, such as for a bulk lookup with
+// "WHERE id IN (" + Placeholders(len(ids), d) + ")".  The arguments are the
+// UUIDs themselves, so they are sent as 16 bytes by Value; use ArgsText or
+// ArgsAs for columns that store them another way.
+func Args(ids []UUID) []interface{} {
+	return ArgsAs(ids, func(u UUID) interface{} { return u })
+}
+
+// ArgsText is like Args but the arguments are the usual text form of each
+// UUID, for text and Postgres uuid columns.
+func ArgsText(ids []UUID) []interface{} {
+	return ArgsAs(ids, func(u UUID) interface{} { return u.String() })
+}
+
+// ArgsAs is like Args but the arguments are made from the UUIDs by conv,
+// such as with UUIDB64 or a MySQLUUID with Swap set.
+func ArgsAs(ids []UUID, conv func(UUID) interface{}) []interface{} {
+	ret := make([]interface{}, len(ids))
+	for i, u := range ids {
+		ret[i] = conv(u)
+	}
+	return ret
+}
+
+// Placeholders returns n comma separated placeholders in the syntax of d,
+// numbered from 1, for the arguments from Args.  Since "IN ()" is not valid
+// SQL, it returns "NULL" for n of 0 or less, which makes "IN (NULL)" match
+// no rows.
+func Placeholders(n int, d Dialect) string { return PlaceholdersAt(1, n, d) }
+
+// PlaceholdersAt is like Placeholders but numbered from first, for when the
+// query has other arguments before them.  first is ignored for
+// DialectQuestion.
+func PlaceholdersAt(first, n int, d Dialect) string {
+	if n <= 0 {
+		return "NULL"
+	}
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		switch d {
+		case DialectDollar:
+			b.WriteByte('$')
+		case DialectColon:
+			b.WriteByte(':')
+		case DialectAtP:
+			b.WriteString("@p")
+		default:
+			b.WriteByte('?')
+			continue
+		}
+		b.WriteString(strconv.Itoa(first + i))
+	}
+	return b.String()
+}
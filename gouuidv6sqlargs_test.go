@@ -0,0 +1,48 @@
+package gouuidv6
+
+import "testing"
+
+func TestArgs(t *testing.T) {
+
+	ids := []UUID{New(), New()}
+
+	args := Args(ids)
+	if len(args) != 2 || args[0] != ids[0] || args[1] != ids[1] {
+		t.Fatalf("Expected the UUIDs as arguments, got %v", args)
+	}
+	args = ArgsText(ids)
+	if len(args) != 2 || args[0] != ids[0].String() {
+		t.Fatalf("Expected the text forms as arguments, got %v", args)
+	}
+	args = ArgsAs(ids, func(u UUID) interface{} { return UUIDB64(u) })
+	if args[1] != UUIDB64(ids[1]) {
+		t.Fatalf("Expected UUIDB64 arguments, got %v", args)
+	}
+	if args := Args(nil); len(args) != 0 {
+		t.Fatalf("Expected no arguments, got %v", args)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+
+	for _, c := range []struct {
+		first, n int
+		d        Dialect
+		want     string
+	}{
+		{1, 3, DialectQuestion, "?, ?, ?"},
+		{1, 3, DialectDollar, "$1, $2, $3"},
+		{3, 2, DialectDollar, "$3, $4"},
+		{1, 2, DialectColon, ":1, :2"},
+		{2, 2, DialectAtP, "@p2, @p3"},
+		{5, 1, DialectQuestion, "?"},
+		{1, 0, DialectDollar, "NULL"},
+	} {
+		if got := PlaceholdersAt(c.first, c.n, c.d); got != c.want {
+			t.Fatalf("Expected %q, got %q", c.want, got)
+		}
+	}
+	if got := Placeholders(2, DialectDollar); got != "$1, $2" {
+		t.Fatalf("Expected %q, got %q", "$1, $2", got)
+	}
+}
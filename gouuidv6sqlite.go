@@ -0,0 +1,70 @@
+package gouuidv6
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// SQLiteMode is how SQLiteUUID stores a UUID.
+type SQLiteMode int
+
+const (
+	// SQLiteBlob stores the 16 bytes in a BLOB column, the most compact form,
+	// which sorts by time.
+	SQLiteBlob SQLiteMode = iota
+	// SQLiteText stores the usual 36 character text form in a TEXT column,
+	// which is readable in the sqlite3 shell and sorts by time too.
+	SQLiteText
+	// SQLiteBase64 stores the 22 character form from UUIDB64 in a TEXT
+	// column.
+	SQLiteBase64
+)
+
+// SQLiteUUID stores a UUID in SQLite in the form Mode selects.  Scan reads
+// any of the forms whatever the Mode, telling them apart by length (16 byte
+// blobs, 36 character text and 22 character base64, as TEXT or BLOB values),
+// so tables where older rows were written differently can be read with the
+// one type.
+type SQLiteUUID struct {
+	UUID UUID
+	Mode SQLiteMode
+}
+
+func (s SQLiteUUID) Value() (driver.Value, error) {
+	switch s.Mode {
+	case SQLiteBlob:
+		return s.UUID[:], nil
+	case SQLiteText:
+		return s.UUID.String(), nil
+	case SQLiteBase64:
+		return UUIDB64(s.UUID).String(), nil
+	}
+	return nil, fmt.Errorf("gouuidv6: unknown SQLiteMode %d", s.Mode)
+}
+
+func (s *SQLiteUUID) Scan(value interface{}) error {
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 16 {
+			copy(s.UUID[:], v)
+			return nil
+		}
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return fmt.Errorf("gouuidv6: cannot convert from sql driver type %T to SQLiteUUID", value)
+	}
+	if len(str) == 22 {
+		u, err := ParseB64(str)
+		s.UUID = UUID(u)
+		return err
+	}
+	u, err := Parse(str)
+	if err != nil {
+		return err
+	}
+	s.UUID = u
+	return nil
+}
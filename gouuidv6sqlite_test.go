@@ -0,0 +1,45 @@
+package gouuidv6
+
+import "testing"
+
+func TestSQLiteUUID(t *testing.T) {
+
+	u := New()
+
+	var values []interface{}
+	for _, mode := range []SQLiteMode{SQLiteBlob, SQLiteText, SQLiteBase64} {
+		v, err := SQLiteUUID{UUID: u, Mode: mode}.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, v)
+	}
+	if b, ok := values[0].([]byte); !ok || len(b) != 16 {
+		t.Fatalf("Expected 16 bytes for SQLiteBlob, got %#v", values[0])
+	}
+	if s, ok := values[1].(string); !ok || s != u.String() {
+		t.Fatalf("Expected %q for SQLiteText, got %#v", u.String(), values[1])
+	}
+	if s, ok := values[2].(string); !ok || len(s) != 22 {
+		t.Fatalf("Expected 22 characters for SQLiteBase64, got %#v", values[2])
+	}
+
+	// drivers may return TEXT columns as []byte too
+	values = append(values, []byte(u.String()), []byte(UUIDB64(u).String()))
+	for _, v := range values {
+		s := SQLiteUUID{Mode: SQLiteText}
+		if err := s.Scan(v); err != nil || s.UUID != u {
+			t.Fatalf("Expected Scan of %#v to give %v, got %v (%v)", v, u, s.UUID, err)
+		}
+	}
+
+	var s SQLiteUUID
+	for _, v := range []interface{}{nil, int64(1), "not a uuid", []byte{1, 2}} {
+		if err := s.Scan(v); err == nil {
+			t.Fatalf("Expected error scanning %#v", v)
+		}
+	}
+	if _, err := (SQLiteUUID{Mode: 9}).Value(); err == nil {
+		t.Fatalf("Expected error for an unknown mode")
+	}
+}
@@ -0,0 +1,105 @@
+package gouuidv6
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"unsafe"
+)
+
+// size of the state file: the last timestamp and clock sequence, 8 bytes each
+const stateFileSize = 16
+
+// StateFile keeps a Generator's last timestamp and clock sequence in a small
+// memory-mapped file, so that a process restarted after a crash carries on
+// where it left off instead of risking repeating UUIDs if the clock has moved
+// backward in the meantime.  The state is updated on every UUID generated
+// with a couple of memory writes and no system calls; the operating system
+// writes it out, so it survives the process crashing but not necessarily the
+// machine losing power.
+//
+//	sf, err := gouuidv6.OpenStateFile("/var/lib/myapp/uuid.state")
+//	if err != nil { ... }
+//	defer sf.Close()
+//	g := gouuidv6.NewGenerator(sf.Option())
+//
+// Only one Generator at a time should use a StateFile.  Memory mapping is
+// only available on unix.
+type StateFile struct {
+	f   *os.File
+	mem []byte
+}
+
+// OpenStateFile opens (creating if needed) and maps the state file at path.
+func OpenStateFile(path string) (*StateFile, error) {
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fi.Size() < stateFileSize {
+		if err := f.Truncate(stateFileSize); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	mem, err := mmapFile(f, stateFileSize)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("gouuidv6: cannot map state file: %v", err)
+	}
+
+	return &StateFile{f: f, mem: mem}, nil
+}
+
+// Return a GeneratorOption that starts the Generator from the saved state, if
+// there is any, and saves its state to the file from then on.  The clock
+// sequence is moved on by one from the saved value, so the first UUID cannot
+// repeat the last one saved.
+func (s *StateFile) Option() GeneratorOption {
+	return func(g *Generator) {
+		if lastts := atomic.LoadUint64(s.word(0)); lastts != 0 {
+			g.setClock(clockState{lastts: lastts, clockseq: uint32(atomic.LoadUint64(s.word(1))) + 1})
+		}
+		g.stateFile = s
+	}
+}
+
+// Unmap and close the file.  Generators using it must not be used afterwards.
+func (s *StateFile) Close() error {
+	err := munmapFile(s.mem)
+	if cerr := s.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Save the timestamp and clock sequence of a new UUID.  Each is only ever
+// raised, so that with concurrent callers the file never goes back to an
+// older state: the saved timestamp is at least that of the last UUID and the
+// clock sequence at least the last one used.
+func (s *StateFile) save(lastts uint64, clockseq uint32) {
+	storeMax(s.word(0), lastts)
+	storeMax(s.word(1), uint64(clockseq))
+}
+
+// Return a pointer to the nth 8-byte word of the mapping.  The mapping is page
+// aligned, so these are suitable for atomic operations.
+func (s *StateFile) word(n int) *uint64 { return (*uint64)(unsafe.Pointer(&s.mem[n*8])) }
+
+// Set *p to v if v is greater.
+func storeMax(p *uint64, v uint64) {
+	for {
+		old := atomic.LoadUint64(p)
+		if old >= v || atomic.CompareAndSwapUint64(p, old, v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package gouuidv6
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStateFile(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "gouuidv6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "uuid.state")
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	sf, err := OpenStateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGenerator(WithNode(0xdeadbeef), WithClockSeq(5), sf.Option())
+	var last UUID
+	for i := 0; i < 3; i++ {
+		last = g.NewFromTime(tim)
+	}
+	if err := sf.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// as if restarted with the clock set back a little
+	sf, err = OpenStateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sf.Close()
+	g = NewGenerator(WithNode(0xdeadbeef), sf.Option())
+	u := g.NewFromTime(tim.Add(-time.Second))
+
+	if u.ClockSeq() != last.ClockSeq()+2 {
+		t.Fatalf("Expected clock sequence %d after restart, got %v", last.ClockSeq()+2, u)
+	}
+	if m := g.Metrics(); m.ClockRegressions != 1 {
+		t.Fatalf("Expected the restored timestamp to show a regression, got %+v", m)
+	}
+
+}
@@ -0,0 +1,73 @@
+package gouuidv6
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// ParseStrict parses only the exact canonical text form of a version 6 UUID,
+// as String returns it: 36 characters of lower case hex with dashes, and the
+// version 6 and RFC 4122 variant bits set.  This is for API gateways and
+// other places that should reject anything else; see Parse for the lenient
+// form.  opts can add further checks such as RequireValid.
+func ParseStrict(us string, opts ...ParseOption) (UUID, error) {
+
+	var ret UUID
+	if len(us) != 36 {
+		return UUID{}, fmt.Errorf("gouuidv6: invalid UUID %q", us)
+	}
+	for i := 0; i < len(us); i++ {
+		if c := us[i]; 'A' <= c && c <= 'F' {
+			return UUID{}, fmt.Errorf("gouuidv6: UUID %q is not lower case", us)
+		}
+	}
+	if ret.DecodeText([]byte(us)) != nil {
+		return UUID{}, fmt.Errorf("gouuidv6: invalid UUID %q", us)
+	}
+	if !ret.isV6() {
+		return UUID{}, fmt.Errorf("gouuidv6: %q is not a version 6 UUID", us)
+	}
+
+	for _, opt := range opts {
+		if err := opt(ret); err != nil {
+			return UUID{}, err
+		}
+	}
+	return ret, nil
+}
+
+// StrictUUID is like UUID but its UnmarshalText and UnmarshalJSON, and so
+// encoding/json, encoding/xml and the like, use ParseStrict rather than the
+// lenient Parse, for the fields of API requests that should only accept the
+// canonical form.  Convert to and from UUID as needed; the two have the same
+// layout.
+type StrictUUID UUID
+
+func (u StrictUUID) MarshalText() ([]byte, error) { return UUID(u).MarshalText() }
+
+func (u *StrictUUID) UnmarshalText(text []byte) error {
+	u2, err := ParseStrict(string(text))
+	if err != nil {
+		return err
+	}
+	*u = StrictUUID(u2)
+	return nil
+}
+
+func (u StrictUUID) MarshalJSON() ([]byte, error) { return UUID(u).MarshalJSON() }
+
+func (u *StrictUUID) UnmarshalJSON(data []byte) error {
+	s := ""
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func (u StrictUUID) Value() (driver.Value, error) { return UUID(u).Value() }
+
+func (u *StrictUUID) Scan(value interface{}) error { return (*UUID)(u).Scan(value) }
+
+// Return the UUID in the usual text form.
+func (u StrictUUID) String() string { return UUID(u).String() }
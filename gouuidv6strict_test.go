@@ -0,0 +1,85 @@
+package gouuidv6
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseLenientAndStrict(t *testing.T) {
+
+	u, _ := Parse(`1ef200e7-8cb6-6000-8005-0000deadbeef`)
+	s := u.String()
+
+	for _, in := range []string{
+		s,
+		strings.ToUpper(s),
+		"  " + s + "\n",
+		"urn:uuid:" + s,
+		"URN:UUID:" + s,
+		"{" + s + "}",
+		strings.ReplaceAll(s, "-", ""),
+	} {
+		got, err := Parse(in)
+		if err != nil || got != u {
+			t.Fatalf("Expected Parse(%q) = %v, got %v (err=%v)", in, u, got, err)
+		}
+		if in == s {
+			continue
+		}
+		if _, err := ParseStrict(in); err == nil {
+			t.Fatalf("Expected ParseStrict to reject %q", in)
+		}
+	}
+
+	for _, in := range []string{"", "1-2-3-4-5", s[:35] + "g", s + "0"} {
+		if _, err := Parse(in); err == nil {
+			t.Fatalf("Expected Parse to reject %q", in)
+		}
+	}
+
+	if got, err := ParseStrict(s); err != nil || got != u {
+		t.Fatalf("Expected ParseStrict(%q) = %v, got %v (err=%v)", s, u, got, err)
+	}
+	if _, err := ParseStrict(`f81d4fae-7dec-11d0-a765-00a0c91e6bf6`); err == nil {
+		t.Fatalf("Expected ParseStrict to reject a version 1 UUID")
+	}
+
+	var v struct{ ID UUID }
+	in := []byte(`{"ID":"` + strings.ToUpper(s) + `"}`)
+	if err := json.Unmarshal(in, &v); err != nil || v.ID != u {
+		t.Fatalf("Expected lenient unmarshal of %s, got %v (err=%v)", in, v.ID, err)
+	}
+
+}
+
+func TestStrictUUID(t *testing.T) {
+
+	u, _ := Parse(`1ef200e7-8cb6-6000-8005-0000deadbeef`)
+	s := u.String()
+
+	var v struct{ ID StrictUUID }
+	in := []byte(`{"ID":"` + s + `"}`)
+	if err := json.Unmarshal(in, &v); err != nil || UUID(v.ID) != u {
+		t.Fatalf("Expected %v from %s, got %v (err=%v)", u, in, v.ID, err)
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != string(in) {
+		t.Fatalf("Expected %s, got %s (err=%v)", in, b, err)
+	}
+
+	for _, bad := range []string{strings.ToUpper(s), "{" + s + "}", `f81d4fae-7dec-11d0-a765-00a0c91e6bf6`} {
+		if err := json.Unmarshal([]byte(`{"ID":"`+bad+`"}`), &v); err == nil {
+			t.Fatalf("Expected StrictUUID to reject %q", bad)
+		}
+		if err := v.ID.UnmarshalText([]byte(bad)); err == nil {
+			t.Fatalf("Expected StrictUUID to reject %q", bad)
+		}
+	}
+
+	// UUID itself is unaffected
+	var lenient UUID
+	if err := lenient.UnmarshalText([]byte(strings.ToUpper(s))); err != nil || lenient != u {
+		t.Fatalf("Expected lenient unmarshal of %q, got %v (err=%v)", strings.ToUpper(s), lenient, err)
+	}
+
+}
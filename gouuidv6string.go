@@ -0,0 +1,44 @@
+package gouuidv6
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// UUIDString is a UUID whose Value is the usual 36 character text form
+// rather than 16 bytes, for drivers and tools that mishandle []byte values
+// for UUID columns, such as lib/pq with a Postgres uuid column (which it
+// sends as bytea) or code generated by sqlc for one.  Convert to and from
+// UUID as needed; the two have the same layout.
+type UUIDString UUID
+
+func (u UUIDString) Value() (driver.Value, error) {
+	return UUID(u).String(), nil
+}
+
+// Scan reads the text form of a UUID as a string or []byte, in any of the
+// forms Parse accepts, or 16 raw bytes.
+func (u *UUIDString) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		s = string(v)
+	default:
+		return fmt.Errorf("gouuidv6: cannot convert from sql driver type %T to UUIDString", value)
+	}
+	u2, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*u = UUIDString(u2)
+	return nil
+}
+
+// Return the UUID in the usual text form.
+func (u UUIDString) String() string { return UUID(u).String() }
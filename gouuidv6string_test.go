@@ -0,0 +1,30 @@
+package gouuidv6
+
+import "testing"
+
+func TestUUIDString(t *testing.T) {
+
+	u := New()
+
+	v, err := UUIDString(u).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := v.(string); !ok || s != u.String() {
+		t.Fatalf("Expected Value %q, got %#v", u.String(), v)
+	}
+
+	for _, in := range []interface{}{u.String(), []byte(u.String()), u[:], "{" + u.String() + "}"} {
+		var us UUIDString
+		if err := us.Scan(in); err != nil || UUID(us) != u {
+			t.Fatalf("Expected Scan of %#v to give %v, got %v (%v)", in, u, us, err)
+		}
+	}
+
+	var us UUIDString
+	for _, in := range []interface{}{nil, int64(1), "nope", []byte{1}} {
+		if err := us.Scan(in); err == nil {
+			t.Fatalf("Expected error scanning %#v", in)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package gouuidv6
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Zero-copy serialization formats such as FlatBuffers and Cap'n Proto have
+// no UUID type, so a UUID field is usually declared as a struct of two
+// 64-bit integers, which they store inline and in little endian order:
+//
+//	struct UUID { hi: ulong; lo: ulong; }                 // FlatBuffers
+//	struct UUID { hi @0 :UInt64; lo @1 :UInt64; }         // Cap'n Proto
+//
+// or as 16 bytes, a [ubyte:16] array in FlatBuffers or Data in Cap'n Proto.
+// Halves and FromHalves convert to and from the two integers for the
+// generated accessors (such as FlatBuffers' CreateUUID(builder, hi, lo) and
+// id.Hi()), PutLittleEndian and FromLittleEndian read and write the struct's
+// 16 bytes directly in a buffer, and FromBytes checks the length of a byte
+// array field.  hi is the first 8 bytes of the UUID read big endian, so
+// comparing hi then lo orders UUIDs by time, as the bytes do.
+
+// Halves returns the UUID as two uint64s, the first and last 8 bytes read
+// big endian.
+func (u UUID) Halves() (hi, lo uint64) {
+	return bigEnd.Uint64(u[:8]), bigEnd.Uint64(u[8:])
+}
+
+// FromHalves returns the UUID from the two uint64s from Halves.
+func FromHalves(hi, lo uint64) UUID {
+	var u UUID
+	bigEnd.PutUint64(u[:8], hi)
+	bigEnd.PutUint64(u[8:], lo)
+	return u
+}
+
+// PutLittleEndian writes the UUID to the first 16 bytes of b as a
+// FlatBuffers or Cap'n Proto struct of hi and lo (see Halves) stores it:
+// hi then lo, each little endian.  It panics if b is shorter than 16 bytes.
+func (u UUID) PutLittleEndian(b []byte) {
+	_ = b[15] // early bounds check
+	hi, lo := u.Halves()
+	binary.LittleEndian.PutUint64(b[:8], hi)
+	binary.LittleEndian.PutUint64(b[8:16], lo)
+}
+
+// FromLittleEndian reads a UUID written by PutLittleEndian from the first 16
+// bytes of b, such as a struct field in a FlatBuffers or Cap'n Proto message,
+// without copying the rest of the buffer.  It panics if b is shorter than 16
+// bytes.
+func FromLittleEndian(b []byte) UUID {
+	_ = b[15] // early bounds check
+	return FromHalves(binary.LittleEndian.Uint64(b[:8]), binary.LittleEndian.Uint64(b[8:16]))
+}
+
+// FromBytes returns the UUID in b, a 16 byte array or Data field, in the
+// same order as Bytes returns it.  It returns an error if b is not 16 bytes.
+func FromBytes(b []byte) (UUID, error) {
+	var u UUID
+	if len(b) != 16 {
+		return u, fmt.Errorf("gouuidv6: %d bytes is not a UUID", len(b))
+	}
+	copy(u[:], b)
+	return u, nil
+}
@@ -0,0 +1,67 @@
+package gouuidv6
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHalves(t *testing.T) {
+
+	u, err := Parse("1eea838b-4cc8-6000-8000-010000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hi, lo := u.Halves()
+	if hi != 0x1eea838b4cc86000 || lo != 0x8000010000000000 {
+		t.Fatalf("Unexpected halves %x %x", hi, lo)
+	}
+	if u2 := FromHalves(hi, lo); u2 != u {
+		t.Fatalf("Expected %v from FromHalves, got %v", u, u2)
+	}
+
+	// later UUIDs compare higher by hi then lo
+	g := NewGenerator()
+	a, b := g.New(), g.New()
+	ahi, alo := a.Halves()
+	bhi, blo := b.Halves()
+	if !(ahi < bhi || ahi == bhi && alo < blo) {
+		t.Fatalf("Expected halves of %v to compare below those of %v", a, b)
+	}
+}
+
+func TestLittleEndian(t *testing.T) {
+
+	u, err := Parse("01020304-0506-6708-8900-0a0b0c0d0e0f")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a struct at offset 4 of a larger buffer, as FlatBuffers lays it out
+	buf := make([]byte, 24)
+	u.PutLittleEndian(buf[4:])
+	want := []byte{0, 0, 0, 0, 0x08, 0x67, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x0f, 0x0e, 0x0d, 0x0c, 0x0b, 0x0a, 0x00, 0x89, 0, 0, 0, 0}
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("Expected %x, got %x", want, buf)
+	}
+	if u2 := FromLittleEndian(buf[4:]); u2 != u {
+		t.Fatalf("Expected %v from FromLittleEndian, got %v", u, u2)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected panic for a short buffer")
+		}
+	}()
+	FromLittleEndian(buf[:15])
+}
+
+func TestFromBytes(t *testing.T) {
+
+	u := New()
+	if u2, err := FromBytes(u.Bytes()); err != nil || u2 != u {
+		t.Fatalf("Expected %v, got %v (%v)", u, u2, err)
+	}
+	if _, err := FromBytes(u[:15]); err == nil {
+		t.Fatalf("Expected error for 15 bytes")
+	}
+}
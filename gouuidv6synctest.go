@@ -0,0 +1,27 @@
+package gouuidv6
+
+import "time"
+
+// Return a Generator for tests that run in a testing/synctest bubble.  It
+// reads the time with time.Now on every call to New, so UUIDs follow the
+// bubble's fake clock, and as with NewDeterministicGenerator its node and
+// starting clock sequence are derived from seed, so a test that makes the
+// same calls gets the same UUIDs every run.  The Generator starts no
+// goroutines and never sleeps or waits, so it does not keep a bubble from
+// becoming durably blocked.
+//
+// Use it (or a Generator created inside the bubble) rather than the package
+// level functions: the default Generator is shared with code outside the
+// bubble, and having seen real times it treats the bubble's fake times
+// (which start at midnight UTC on 1 January 2000) as the clock moving
+// backward.  The fake clock only advances when every goroutine in the bubble
+// is blocked, so UUIDs created without a time.Sleep between them share a
+// timestamp and are kept apart by the clock sequence.  After 16384 of them
+// (fewer with WithClockSeqPartition) the Generator moves the timestamp on
+// by 100ns to keep them unique, so the times of the UUIDs run ahead of the
+// fake clock, and Time no longer equals time.Now when they were created,
+// until it next advances past them.
+func NewSynctestGenerator(seed int64, opts ...GeneratorOption) *Generator {
+	opts = append([]GeneratorOption{WithTimeFunc(time.Now)}, opts...)
+	return NewDeterministicGenerator(seed, time.Time{}, opts...)
+}
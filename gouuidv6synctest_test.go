@@ -0,0 +1,86 @@
+//go:build go1.25
+
+package gouuidv6
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestSynctestGenerator(t *testing.T) {
+
+	var first []UUID
+	for run := 0; run < 2; run++ {
+		synctest.Test(t, func(t *testing.T) {
+
+			g := NewSynctestGenerator(42)
+
+			var uuids []UUID
+			start := time.Now()
+			for i := 0; i < 3; i++ {
+				uuids = append(uuids, g.New(), g.New())
+				time.Sleep(time.Second)
+			}
+
+			for i, u := range uuids {
+				if !u.IsValid() {
+					t.Fatalf("Expected valid UUID at %d, got %v", i, u)
+				}
+				if want := start.Add(time.Duration(i/2) * time.Second); !u.Time().Equal(want) {
+					t.Fatalf("Expected time %v at %d, got %v", want, i, u.Time())
+				}
+				if i > 0 && !less(uuids[i-1], u) {
+					t.Fatalf("Expected %v before %v", uuids[i-1], u)
+				}
+			}
+			if uuids[0].ClockSeq()+1 != uuids[1].ClockSeq() {
+				t.Fatalf("Expected clock sequence to increment within a tick, got %v then %v", uuids[0], uuids[1])
+			}
+
+			if first == nil {
+				first = uuids
+				return
+			}
+			for i := range uuids {
+				if uuids[i] != first[i] {
+					t.Fatalf("Expected the same UUID at %d every run, got %v and %v", i, first[i], uuids[i])
+				}
+			}
+		})
+	}
+
+	// more UUIDs than there are clock sequence values without the fake
+	// clock moving
+	synctest.Test(t, func(t *testing.T) {
+		g := NewSynctestGenerator(42)
+		now := time.Now()
+		seen := make(map[UUID]bool)
+		var last UUID
+		for i := 0; i < 3*0x4000; i++ {
+			u := g.New()
+			if seen[u] {
+				t.Fatalf("Duplicate UUID %v at %d", u, i)
+			}
+			seen[u] = true
+			if i > 0 && !less(last, u) {
+				t.Fatalf("Expected %v before %v", last, u)
+			}
+			last = u
+		}
+		if !time.Now().Equal(now) || !last.Time().After(now) {
+			t.Fatalf("Expected the last UUID ahead of the fake clock at %v, got %v", time.Now(), last.Time())
+		}
+		time.Sleep(time.Second)
+		if u := g.New(); !u.Time().Equal(time.Now()) {
+			t.Fatalf("Expected time %v once the fake clock moved on, got %v", time.Now(), u.Time())
+		}
+	})
+
+	synctest.Test(t, func(t *testing.T) {
+		g := NewSynctestGenerator(42, WithNode(0x0A0B0C0D0E0F))
+		if n := g.New().Node().String(); n != "0a:0b:0c:0d:0e:0f" {
+			t.Fatalf("Expected options to override the node, got %v", n)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package gouuidv6test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// Clock is a simulated clock for checking how a Generator configuration
+// copes with a misbehaving system clock.  It only moves when told to, can be
+// stepped backward as well as forward, and can add random jitter to every
+// reading.  A Clock is safe for concurrent use.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	jitter time.Duration
+	rand   *rand.Rand
+}
+
+// NewClock returns a Clock reading start.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start, rand: rand.New(rand.NewSource(1))}
+}
+
+// Now returns the clock's time, plus or minus up to the jitter.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.jitter <= 0 {
+		return c.now
+	}
+	return c.now.Add(time.Duration(c.rand.Int63n(int64(2*c.jitter)+1)) - c.jitter)
+}
+
+// Set sets the clock to t, which may be earlier than its current time.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
+
+// Advance moves the clock by d, backward if d is negative.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// SetJitter makes every reading off by a random amount up to max either way,
+// from a sequence determined by seed.  A max of 0 turns jitter off.
+func (c *Clock) SetJitter(max time.Duration, seed int64) {
+	c.mu.Lock()
+	c.jitter, c.rand = max, rand.New(rand.NewSource(seed))
+	c.mu.Unlock()
+}
+
+// Option returns a GeneratorOption making a Generator read the time from c.
+func (c *Clock) Option() gouuidv6.GeneratorOption { return gouuidv6.WithTimeFunc(c.Now) }
+
+// Regression is a call of a Generator's OnClockRegression function.
+type Regression struct {
+	Prev, Now time.Time
+	ClockSeq  uint16
+}
+
+// Harness is a Generator wired to a Clock that records every UUID it creates,
+// every clock reading and every clock regression reported, so tests can
+// drive the clock through a scenario and then check the Generator coped.
+// The assertions assume UUIDs are created from one goroutine at a time.
+type Harness struct {
+	Clock     *Clock
+	Generator *gouuidv6.Generator
+
+	mu          sync.Mutex
+	uuids       []gouuidv6.UUID
+	reads       []time.Time
+	regressions []Regression
+}
+
+// NewHarness returns a Harness with a Generator created with opts, reading
+// the time from clock.  opts must not include another time source.
+func NewHarness(clock *Clock, opts ...gouuidv6.GeneratorOption) *Harness {
+	h := &Harness{Clock: clock}
+	now := func() time.Time {
+		t := clock.Now()
+		h.mu.Lock()
+		h.reads = append(h.reads, t)
+		h.mu.Unlock()
+		return t
+	}
+	h.Generator = gouuidv6.NewGenerator(append(opts, gouuidv6.WithTimeFunc(now))...)
+	h.Generator.OnClockRegression(func(prev, now time.Time, clockseq uint16) {
+		h.mu.Lock()
+		h.regressions = append(h.regressions, Regression{prev, now, clockseq})
+		h.mu.Unlock()
+	})
+	return h
+}
+
+// New creates and records a UUID with the Generator.
+func (h *Harness) New() gouuidv6.UUID {
+	u := h.Generator.New()
+	h.mu.Lock()
+	h.uuids = append(h.uuids, u)
+	h.mu.Unlock()
+	return u
+}
+
+// Generate creates n UUIDs, advancing the clock by step after each.
+func (h *Harness) Generate(n int, step time.Duration) {
+	for i := 0; i < n; i++ {
+		h.New()
+		h.Clock.Advance(step)
+	}
+}
+
+// UUIDs returns the UUIDs created so far, in order.
+func (h *Harness) UUIDs() []gouuidv6.UUID {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]gouuidv6.UUID(nil), h.uuids...)
+}
+
+// Regressions returns the clock regressions reported so far, in order.
+func (h *Harness) Regressions() []Regression {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Regression(nil), h.regressions...)
+}
+
+// AssertUnique fails t if any two UUIDs created were the same.
+func (h *Harness) AssertUnique(t testing.TB) {
+	t.Helper()
+	seen := make(map[gouuidv6.UUID]int)
+	for i, u := range h.UUIDs() {
+		if j, ok := seen[u]; ok {
+			t.Fatalf("UUID %d is a duplicate of UUID %d: %v", i, j, u)
+		}
+		seen[u] = i
+	}
+}
+
+// AssertRegressions fails t unless the Generator counted every backward step
+// of the clock between readings as a regression, and reported exactly those
+// of more than tolerance to OnClockRegression; tolerance should be what was
+// given to WithRegressionTolerance, or 0.  It assumes the Generator was not
+// created with WithPrecision.
+func (h *Harness) AssertRegressions(t testing.TB, tolerance time.Duration) {
+	t.Helper()
+
+	h.mu.Lock()
+	reads := append([]time.Time(nil), h.reads...)
+	reported := len(h.regressions)
+	h.mu.Unlock()
+
+	// compare readings as UUIDs see them, in 100ns ticks
+	steps, big := 0, 0
+	for i := 1; i < len(reads); i++ {
+		prev, now := reads[i-1].UnixNano()/100, reads[i].UnixNano()/100
+		if prev > now {
+			steps++
+			if time.Duration(prev-now)*100 > tolerance {
+				big++
+			}
+		}
+	}
+
+	if m := h.Generator.Metrics(); m.ClockRegressions != uint64(steps) {
+		t.Fatalf("Clock moved backward %d times but the Generator counted %d regressions", steps, m.ClockRegressions)
+	}
+	if reported != big {
+		t.Fatalf("Clock moved backward by more than %v %d times but %d regressions were reported", tolerance, big, reported)
+	}
+}
@@ -0,0 +1,59 @@
+package gouuidv6test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestHarness(t *testing.T) {
+
+	clock := NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	h := NewHarness(clock, gouuidv6.WithRegressionTolerance(time.Millisecond))
+
+	h.Generate(100, time.Microsecond)
+	clock.Advance(-100 * time.Microsecond) // small NTP-like step
+	h.Generate(100, 0)                     // stuck clock
+	clock.Advance(-time.Hour)              // big step
+	h.Generate(10, time.Microsecond)
+	clock.SetJitter(50*time.Microsecond, 42)
+	h.Generate(1000, 10*time.Microsecond)
+
+	h.AssertUnique(t)
+	h.AssertRegressions(t, time.Millisecond)
+
+	if r := h.Regressions(); len(r) != 1 || r[0].Prev.Sub(r[0].Now) < time.Hour {
+		t.Fatalf("Expected one big regression reported, got %v", r)
+	}
+	if n := len(h.UUIDs()); n != 1210 {
+		t.Fatalf("Expected 1210 UUIDs, got %d", n)
+	}
+
+}
+
+func TestClock(t *testing.T) {
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewClock(start)
+	if !c.Now().Equal(start) {
+		t.Fatalf("Expected %v, got %v", start, c.Now())
+	}
+	c.Advance(-time.Second)
+	if !c.Now().Equal(start.Add(-time.Second)) {
+		t.Fatalf("Expected clock to go back")
+	}
+	c.Set(start)
+	c.SetJitter(time.Millisecond, 1)
+	for i := 0; i < 100; i++ {
+		if d := c.Now().Sub(start); d < -time.Millisecond || d > time.Millisecond {
+			t.Fatalf("Jitter %v out of range", d)
+		}
+	}
+
+	g := gouuidv6.NewGenerator(c.Option())
+	if d := g.New().Time().Sub(start); d < -time.Millisecond || d > time.Millisecond {
+		t.Fatalf("Expected Generator to use the clock, got offset %v", d)
+	}
+
+}
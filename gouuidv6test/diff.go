@@ -0,0 +1,70 @@
+package gouuidv6test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// DiffUUID returns "" if expected and actual are equal, and otherwise a
+// description of how their decoded fields differ, e.g.
+//
+//	expected 1eea838b-4cc8-6000-8000-010000000000, got 1eea838b-4cc8-6010-8000-010000000000
+//	  time: +1.6µs (2024-01-01T00:00:00Z, got 2024-01-01T00:00:00.0000016Z)
+//
+// which makes test failures easier to read than two hex strings.
+func DiffUUID(expected, actual gouuidv6.UUID) string {
+
+	if expected == actual {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "expected %v, got %v", expected, actual)
+
+	if ev, av := expected.Version(), actual.Version(); ev != av {
+		fmt.Fprintf(&b, "\n  version: %d, got %d", ev, av)
+	}
+	if ev, av := expected.Variant(), actual.Variant(); ev != av {
+		fmt.Fprintf(&b, "\n  variant: %d, got %d", ev, av)
+	}
+	if et, at := expected.GregorianTimestamp(), actual.GregorianTimestamp(); et != at {
+		// timestamps are 60 bits so the difference in ticks always fits, but
+		// it can be too long for a time.Duration
+		ticks := int64(at) - int64(et)
+		if ticks > math.MaxInt64/100 || ticks < math.MinInt64/100 {
+			fmt.Fprintf(&b, "\n  time: %+d ticks of 100ns", ticks)
+		} else {
+			delta := time.Duration(ticks * 100)
+			sign := "+"
+			if delta < 0 {
+				sign = ""
+			}
+			fmt.Fprintf(&b, "\n  time: %s%v", sign, delta)
+		}
+		if eok, aok := expected.IsValid(), actual.IsValid(); eok && aok {
+			fmt.Fprintf(&b, " (%s, got %s)", expected.Time().UTC().Format(time.RFC3339Nano), actual.Time().UTC().Format(time.RFC3339Nano))
+		}
+	}
+	if ec, ac := expected.ClockSeq(), actual.ClockSeq(); ec != ac {
+		fmt.Fprintf(&b, "\n  clockseq: %d, got %d (%+d)", ec, ac, int(ac)-int(ec))
+	}
+	if en, an := expected.Node(), actual.Node(); en.String() != an.String() {
+		fmt.Fprintf(&b, "\n  node: %v, got %v", en, an)
+	}
+
+	return b.String()
+}
+
+// AssertEqual fails t with the DiffUUID of expected and actual if they are
+// not equal.
+func AssertEqual(t testing.TB, expected, actual gouuidv6.UUID) {
+	t.Helper()
+	if d := DiffUUID(expected, actual); d != "" {
+		t.Fatal(d)
+	}
+}
@@ -0,0 +1,49 @@
+package gouuidv6test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestDiffUUID(t *testing.T) {
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSequence(start, 1600*time.Nanosecond)
+	a, b := s.Next(), s.Next()
+
+	if d := DiffUUID(a, a); d != "" {
+		t.Fatalf("Expected no difference, got %q", d)
+	}
+
+	d := DiffUUID(a, b)
+	if !strings.Contains(d, "time: +1.6µs (2024-01-01T00:00:00Z, got 2024-01-01T00:00:00.0000016Z)") {
+		t.Fatalf("Expected time difference, got:\n%s", d)
+	}
+	if strings.Contains(d, "clockseq") || strings.Contains(d, "node") {
+		t.Fatalf("Expected only the time to differ, got:\n%s", d)
+	}
+
+	c := gouuidv6.FromGregorianTimestamp(a.GregorianTimestamp(), 5, 0x0242ac110002)
+	d = DiffUUID(a, c)
+	if !strings.Contains(d, "clockseq: 0, got 5 (+5)") || !strings.Contains(d, "node: 01:00:00:00:00:00, got 02:42:ac:11:00:02") || strings.Contains(d, "time") {
+		t.Fatalf("Expected clockseq and node differences, got:\n%s", d)
+	}
+
+	// too far apart for a time.Duration
+	z := gouuidv6.NewFromTime(start)
+	d = DiffUUID(z, gouuidv6.UUID{})
+	if want := fmt.Sprintf("time: %+d ticks of 100ns", -int64(z.GregorianTimestamp())); !strings.Contains(d, want) {
+		t.Fatalf("Expected %q, got:\n%s", want, d)
+	}
+	d = DiffUUID(gouuidv6.UUID{}, z)
+	if want := fmt.Sprintf("time: %+d ticks of 100ns", z.GregorianTimestamp()); !strings.Contains(d, want) {
+		t.Fatalf("Expected %q, got:\n%s", want, d)
+	}
+
+	AssertEqual(t, a, a)
+
+}
@@ -0,0 +1,59 @@
+// Package gouuidv6test provides helpers for testing code that uses package
+// gouuidv6, in the manner of net/http/httptest.
+package gouuidv6test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// SequenceNode is the node of every UUID from a Sequence, 01:00:00:00:00:00,
+// chosen to stand out and to have the multicast bit set.
+const SequenceNode = uint64(0x010000000000)
+
+// Sequence yields predictable, strictly increasing UUIDs, for tests that
+// make assertions about ordering and pagination.  The n'th UUID (from 0) has
+// the time start + n*step, clock sequence 0 and node SequenceNode, so UUIDs
+// differ only in their time and are easy to read in failure messages.  If
+// step is less than 100ns, the resolution of UUID times, consecutive UUIDs
+// with the same time get increasing clock sequences instead.  A Sequence is
+// safe for concurrent use.
+type Sequence struct {
+	mu       sync.Mutex
+	next     time.Time
+	step     time.Duration
+	prevTS   uint64
+	clockseq uint16
+	started  bool
+}
+
+// NewSequence returns a Sequence starting at start and advancing by step.
+func NewSequence(start time.Time, step time.Duration) *Sequence {
+	return &Sequence{next: start, step: step}
+}
+
+// Next returns the next UUID in the sequence.  It panics if the sequence
+// would stop increasing, when the clock sequence runs out after 16384 UUIDs
+// with the same time.
+func (s *Sequence) Next() gouuidv6.UUID {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := gouuidv6.FirstForTime(s.next).GregorianTimestamp()
+	if s.started && ts <= s.prevTS {
+		ts = s.prevTS
+		s.clockseq++
+		if s.clockseq >= 0x4000 {
+			panic("gouuidv6test: Sequence clock sequence exhausted; use a step of at least 100ns")
+		}
+	} else {
+		s.clockseq = 0
+	}
+	s.prevTS, s.started = ts, true
+	s.next = s.next.Add(s.step)
+
+	return gouuidv6.FromGregorianTimestamp(ts, s.clockseq, SequenceNode)
+}
@@ -0,0 +1,35 @@
+package gouuidv6test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSequence(t *testing.T) {
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSequence(start, time.Second)
+
+	u0, u1 := s.Next(), s.Next()
+	if u0.String() != "1eea838b-4cc8-6000-8000-010000000000" {
+		t.Fatalf("Unexpected first UUID %v", u0)
+	}
+	if !u0.Time().Equal(start) || !u1.Time().Equal(start.Add(time.Second)) {
+		t.Fatalf("Expected times %v and %v, got %v and %v", start, start.Add(time.Second), u0.Time(), u1.Time())
+	}
+	if NewSequence(start, time.Second).Next() != u0 {
+		t.Fatalf("Expected sequences to be repeatable")
+	}
+
+	// steps below the UUID resolution still increase
+	s = NewSequence(start, time.Nanosecond)
+	prev := s.Next()
+	for i := 0; i < 1000; i++ {
+		u := s.Next()
+		if u.String() <= prev.String() || !u.IsValid() {
+			t.Fatalf("Expected %v after %v", u, prev)
+		}
+		prev = u
+	}
+
+}
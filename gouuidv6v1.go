@@ -0,0 +1,37 @@
+package gouuidv6
+
+import "fmt"
+
+// FromV1 converts a version 1 UUID to the equivalent version 6 UUID.  The
+// timestamp, clock sequence and node are preserved, only the order of the
+// timestamp fields changes (so the result sorts by time).
+func FromV1(u UUID) (UUID, error) {
+
+	if u.Version() != 1 {
+		return UUID{}, fmt.Errorf("cannot convert version %d UUID from version 1", u.Version())
+	}
+
+	// time_low, time_mid and time_hi, in that order
+	t := uint64(bigEnd.Uint32(u[:4])) | uint64(bigEnd.Uint16(u[4:6]))<<32 | uint64(bigEnd.Uint16(u[6:8])&0x0FFF)<<48
+
+	ret := u
+	bigEnd.PutUint64(ret[:8], ((t<<4)&0xFFFFFFFFFFFF0000)|(t&0x0FFF)|0x6000)
+	return ret, nil
+}
+
+// ToV1 converts a version 6 UUID to the equivalent version 1 UUID, the
+// reverse of FromV1.
+func (u UUID) ToV1() (UUID, error) {
+
+	if u.Version() != 6 {
+		return UUID{}, fmt.Errorf("cannot convert version %d UUID to version 1", u.Version())
+	}
+
+	t := u.timestamp()
+
+	ret := u
+	bigEnd.PutUint32(ret[:4], uint32(t))
+	bigEnd.PutUint16(ret[4:6], uint16(t>>32))
+	bigEnd.PutUint16(ret[6:8], uint16(t>>48)|0x1000)
+	return ret, nil
+}
@@ -0,0 +1,57 @@
+package gouuidv6
+
+import "testing"
+
+func TestV1(t *testing.T) {
+
+	// v1 example from RFC 4122 and the same value as version 6
+	v1, _ := Parse(`f81d4fae-7dec-11d0-a765-00a0c91e6bf6`)
+	v6, _ := Parse(`1d07decf-81d4-6fae-a765-00a0c91e6bf6`)
+
+	u, err := FromV1(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u != v6 {
+		t.Fatalf("FromV1 expected %v, got %v", v6, u)
+	}
+
+	u, err = v6.ToV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u != v1 {
+		t.Fatalf("ToV1 expected %v, got %v", v1, u)
+	}
+
+	// round trip a freshly generated one
+	n := New()
+	u, _ = n.ToV1()
+	if u, _ = FromV1(u); u != n {
+		t.Fatalf("Round trip through version 1 changed %v to %v", n, u)
+	}
+
+	if _, err := FromV1(v6); err == nil {
+		t.Fatalf("FromV1 should fail for a version 6 UUID")
+	}
+	if _, err := v1.ToV1(); err == nil {
+		t.Fatalf("ToV1 should fail for a version 1 UUID")
+	}
+
+}
+
+func TestGregorianTimestamp(t *testing.T) {
+
+	v1, _ := Parse(`f81d4fae-7dec-11d0-a765-00a0c91e6bf6`)
+	v6, _ := Parse(`1d07decf-81d4-6fae-a765-00a0c91e6bf6`)
+
+	ts := v6.GregorianTimestamp()
+	if ts != 0x1d07decf81d4fae || v1.GregorianTimestamp() != ts {
+		t.Fatalf("Unexpected timestamps %x and %x", ts, v1.GregorianTimestamp())
+	}
+
+	if u := FromGregorianTimestamp(ts, v6.ClockSeq(), 0x00a0c91e6bf6); u != v6 {
+		t.Fatalf("Expected %v from FromGregorianTimestamp, got %v", v6, u)
+	}
+
+}
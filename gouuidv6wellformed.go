@@ -0,0 +1,45 @@
+package gouuidv6
+
+// Regular expressions matching the text forms of UUIDs, for router
+// constraints and schema validation.  They are unanchored so they can be
+// embedded in route patterns; anchor them with ^ and $ to match whole
+// strings.  Like IsWellFormedString they check only the form, not the
+// version or time (see IsValid).
+const (
+	// the standard hex form, in either case
+	HexPattern = `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`
+	// the base64 form of UUIDB64, using Base64UUIDAlphabet
+	Base64Pattern = `[-0-9A-Z_a-z]{22}`
+)
+
+// IsWellFormedString reports whether s has the form of a UUID in either the
+// standard hex form or the base64 form of UUIDB64, without allocating or
+// decoding it.  It does not check the version or time; use Parse or
+// BindParam for that.
+func IsWellFormedString(s string) bool {
+	switch len(s) {
+	case 36:
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if i == 8 || i == 13 || i == 18 || i == 23 {
+				if c != '-' {
+					return false
+				}
+				continue
+			}
+			if _, ok := unhex(c); !ok {
+				return false
+			}
+		}
+		return true
+	case 22:
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if !(c == '-' || c == '_' || ('0' <= c && c <= '9') || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package gouuidv6
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestIsWellFormedString(t *testing.T) {
+
+	hexRE := regexp.MustCompile("^" + HexPattern + "$")
+	b64RE := regexp.MustCompile("^" + Base64Pattern + "$")
+
+	u := New()
+	for s, want := range map[string]bool{
+		u.String():                              true,
+		strings.ToUpper(u.String()):             true,
+		UUIDB64(u).String():                     true,
+		"":                                      false,
+		"nope":                                  false,
+		"1ef9c3a2-7b4d-6000-8000-00000000000g":  false,
+		"1ef9c3a2-7b4d-6000-8000_000000000000":  false,
+		"1ef9c3a27b4d60008000000000000000":      false,
+		"1ef9c3a2-7b4d-6000-8000-0000000000000": false,
+		"--------------------+-":                false,
+	} {
+		if got := IsWellFormedString(s); got != want {
+			t.Fatalf("Expected IsWellFormedString(%q) = %v, got %v", s, want, got)
+		}
+		if got := hexRE.MatchString(s) || b64RE.MatchString(s); got != want {
+			t.Fatalf("Expected patterns to match %q = %v, got %v", s, want, got)
+		}
+	}
+
+	s := u.String()
+	if n := testing.AllocsPerRun(10, func() { IsWellFormedString(s) }); n > 0 {
+		t.Fatalf("Expected no allocations, got %v", n)
+	}
+
+}
@@ -0,0 +1,141 @@
+// Package grpcid provides gRPC interceptors that carry a "Version 6" UUID
+// request ID between services in the x-request-id metadata key, the gRPC
+// counterpart of package httpid.  Server interceptors take the ID from the
+// incoming metadata if the client sent a valid one, or generate a new one,
+// and store it in the context (see gouuidv6.NewContext) and the response
+// header.  Client interceptors send the ID in the context, generating one if
+// there is none.
+//
+//	s := grpc.NewServer(
+//		grpc.UnaryInterceptor(grpcid.UnaryServerInterceptor()),
+//		grpc.StreamInterceptor(grpcid.StreamServerInterceptor()),
+//	)
+package grpcid
+
+import (
+	"context"
+
+	"github.com/bradleypeabody/gouuidv6"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultKey is the metadata key used when Interceptor.Key is empty.  It is
+// the same as the HTTP header used by package httpid, as gRPC gateways
+// translate one into the other.
+const DefaultKey = "x-request-id"
+
+// Interceptor creates interceptors that propagate request IDs.  The zero
+// value is ready to use.
+type Interceptor struct {
+	// Generator used to create new IDs, the package default if nil.
+	Generator *gouuidv6.Generator
+	// Metadata key holding the ID, DefaultKey if empty.  Must be lower case.
+	Key string
+	// Always generate a new ID in server interceptors, ignoring any sent by
+	// the client.
+	IgnoreIncoming bool
+}
+
+// UnaryServerInterceptor returns the default Interceptor's UnaryServer.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor { return (&Interceptor{}).UnaryServer() }
+
+// StreamServerInterceptor returns the default Interceptor's StreamServer.
+func StreamServerInterceptor() grpc.StreamServerInterceptor { return (&Interceptor{}).StreamServer() }
+
+// UnaryClientInterceptor returns the default Interceptor's UnaryClient.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor { return (&Interceptor{}).UnaryClient() }
+
+// StreamClientInterceptor returns the default Interceptor's StreamClient.
+func StreamClientInterceptor() grpc.StreamClientInterceptor { return (&Interceptor{}).StreamClient() }
+
+// UnaryServer returns a server interceptor for unary calls.
+func (i *Interceptor) UnaryServer() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(i.serverContext(ctx), req)
+	}
+}
+
+// StreamServer returns a server interceptor for streaming calls.
+func (i *Interceptor) StreamServer() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: i.serverContext(ss.Context())})
+	}
+}
+
+// UnaryClient returns a client interceptor for unary calls.
+func (i *Interceptor) UnaryClient() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(i.clientContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClient returns a client interceptor for streaming calls.
+func (i *Interceptor) StreamClient() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(i.clientContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// Return ctx with the request ID from the incoming metadata or a new one,
+// having also set it in the response header.
+func (i *Interceptor) serverContext(ctx context.Context) context.Context {
+
+	key := i.key()
+
+	id, ok := gouuidv6.UUID{}, false
+	if md, mdok := metadata.FromIncomingContext(ctx); mdok && !i.IgnoreIncoming {
+		if v := md.Get(key); len(v) > 0 {
+			id, ok = parseIncoming(v[0])
+		}
+	}
+	if !ok {
+		id = i.newID()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(key, id.String()))
+	return gouuidv6.NewContext(ctx, id)
+}
+
+// Return ctx with the request ID in ctx (or a new one) added to the
+// outgoing metadata.
+func (i *Interceptor) clientContext(ctx context.Context) context.Context {
+	id, ok := gouuidv6.FromContext(ctx)
+	if !ok {
+		id = i.newID()
+		ctx = gouuidv6.NewContext(ctx, id)
+	}
+	return metadata.AppendToOutgoingContext(ctx, i.key(), id.String())
+}
+
+func (i *Interceptor) key() string {
+	if i.Key == "" {
+		return DefaultKey
+	}
+	return i.Key
+}
+
+func (i *Interceptor) newID() gouuidv6.UUID {
+	if i.Generator != nil {
+		return i.Generator.New()
+	}
+	return gouuidv6.New()
+}
+
+// serverStream replaces the context of a grpc.ServerStream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// Return the ID sent by the client if it is a valid version 6 UUID in the
+// standard form.
+func parseIncoming(s string) (gouuidv6.UUID, bool) {
+	var id gouuidv6.UUID
+	if s == "" || id.DecodeText([]byte(s)) != nil || !id.IsValid() {
+		return gouuidv6.UUID{}, false
+	}
+	return id, true
+}
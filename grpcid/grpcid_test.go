@@ -0,0 +1,68 @@
+package grpcid
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryServer(t *testing.T) {
+
+	var got gouuidv6.UUID
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id, ok := gouuidv6.FromContext(ctx)
+		if !ok {
+			t.Fatalf("Expected request ID in context")
+		}
+		got = id
+		return nil, nil
+	}
+	call := func(md metadata.MD) {
+		ctx := context.Background()
+		if md != nil {
+			ctx = metadata.NewIncomingContext(ctx, md)
+		}
+		UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	}
+
+	call(nil)
+	if !got.IsValid() {
+		t.Fatalf("Expected a new ID, got %v", got)
+	}
+
+	in := gouuidv6.NewFromTime(time.Now().Add(-time.Minute))
+	if call(metadata.Pairs(DefaultKey, in.String())); got != in {
+		t.Fatalf("Expected incoming ID %v, got %v", in, got)
+	}
+
+	if call(metadata.Pairs(DefaultKey, "nope")); !got.IsValid() || got == in {
+		t.Fatalf("Expected invalid incoming ID to be replaced, got %v", got)
+	}
+
+}
+
+func TestUnaryClient(t *testing.T) {
+
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(DefaultKey)
+		return nil
+	}
+
+	id := gouuidv6.New()
+	UnaryClientInterceptor()(gouuidv6.NewContext(context.Background(), id), "/m", nil, nil, nil, invoker)
+	if len(sent) != 1 || sent[0] != id.String() {
+		t.Fatalf("Expected %v to be sent, got %v", id, sent)
+	}
+
+	UnaryClientInterceptor()(context.Background(), "/m", nil, nil, nil, invoker)
+	if len(sent) != 1 || sent[0] == id.String() {
+		t.Fatalf("Expected a new ID to be sent, got %v", sent)
+	}
+
+}
@@ -0,0 +1,75 @@
+// Package httpid provides net/http middleware that gives every request a
+// "Version 6" UUID request ID, taken from the X-Request-ID header if the
+// client sent a valid one or newly generated otherwise.  The ID is stored in
+// the request context (see gouuidv6.NewContext) and set on the response.
+//
+//	http.ListenAndServe(":8080", httpid.Handler(mux))
+//
+// and in a handler:
+//
+//	id, _ := httpid.FromContext(r.Context())
+package httpid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// DefaultHeader is the header read and written when Middleware.Header is empty.
+const DefaultHeader = "X-Request-ID"
+
+// Middleware assigns request IDs.  The zero value is ready to use.
+type Middleware struct {
+	// Generator used to create new IDs, the package default if nil.
+	Generator *gouuidv6.Generator
+	// Header holding the ID, DefaultHeader if empty.
+	Header string
+	// Always generate a new ID, ignoring any sent by the client.
+	IgnoreIncoming bool
+}
+
+// Handler returns next wrapped with the default Middleware.
+func Handler(next http.Handler) http.Handler { return (&Middleware{}).Handler(next) }
+
+// Handler returns next wrapped so that each request has an ID.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+
+	header := m.Header
+	if header == "" {
+		header = DefaultHeader
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		id, ok := gouuidv6.UUID{}, false
+		if !m.IgnoreIncoming {
+			id, ok = parseIncoming(r.Header.Get(header))
+		}
+		if !ok {
+			if m.Generator != nil {
+				id = m.Generator.New()
+			} else {
+				id = gouuidv6.New()
+			}
+		}
+
+		w.Header().Set(header, id.String())
+		next.ServeHTTP(w, r.WithContext(gouuidv6.NewContext(r.Context(), id)))
+	})
+}
+
+// FromContext returns the request ID stored by the middleware, if any.  It is
+// the same as gouuidv6.FromContext.
+func FromContext(ctx context.Context) (gouuidv6.UUID, bool) { return gouuidv6.FromContext(ctx) }
+
+// Return the ID sent by the client if it is a valid version 6 UUID in the
+// standard form.
+func parseIncoming(s string) (gouuidv6.UUID, bool) {
+	var id gouuidv6.UUID
+	if s == "" || id.DecodeText([]byte(s)) != nil || !id.IsValid() {
+		return gouuidv6.UUID{}, false
+	}
+	return id, true
+}
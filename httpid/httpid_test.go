@@ -0,0 +1,64 @@
+package httpid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestHandler(t *testing.T) {
+
+	var got gouuidv6.UUID
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := FromContext(r.Context())
+		if !ok {
+			t.Fatalf("Expected request ID in context")
+		}
+		got = id
+	}))
+
+	serve := func(incoming string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/", nil)
+		if incoming != "" {
+			r.Header.Set(DefaultHeader, incoming)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	// new ID
+	w := serve("")
+	if !got.IsValid() || w.Header().Get(DefaultHeader) != got.String() {
+		t.Fatalf("Expected new ID %v in the response, got %q", got, w.Header().Get(DefaultHeader))
+	}
+
+	// valid incoming ID is kept
+	in := gouuidv6.NewFromTime(time.Now().Add(-time.Minute))
+	if serve(in.String()); got != in {
+		t.Fatalf("Expected incoming ID %v to be used, got %v", in, got)
+	}
+
+	// invalid ones are replaced
+	for _, s := range []string{"nope", "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"} {
+		if w := serve(s); got.String() == s || w.Header().Get(DefaultHeader) != got.String() {
+			t.Fatalf("Expected invalid incoming ID %q to be replaced, got %v", s, got)
+		}
+	}
+
+	// or all incoming ones, if asked
+	h = (&Middleware{Header: "X-Trace", IgnoreIncoming: true}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext(r.Context())
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Trace", in.String())
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if got == in || w.Header().Get("X-Trace") != got.String() {
+		t.Fatalf("Expected a new ID in X-Trace, got %v", got)
+	}
+
+}
@@ -0,0 +1,43 @@
+// Package franzid provides a franz-go partitioner for Kafka topics keyed by
+// "Version 6" UUIDs, using kafkaid.Partition.  Records with other keys, or
+// none, are partitioned by kgo.StickyKeyPartitioner.
+//
+//	client, err := kgo.NewClient(kgo.RecordPartitioner(franzid.Partitioner()), ...)
+package franzid
+
+import (
+	"github.com/bradleypeabody/gouuidv6/kafkaid"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Partitioner returns a kgo.Partitioner, for kgo.RecordPartitioner, falling
+// back to kgo.StickyKeyPartitioner.
+func Partitioner() kgo.Partitioner {
+	return partitioner{fallback: kgo.StickyKeyPartitioner(nil)}
+}
+
+type partitioner struct {
+	fallback kgo.Partitioner
+}
+
+func (p partitioner) ForTopic(topic string) kgo.TopicPartitioner {
+	return &topicPartitioner{fallback: p.fallback.ForTopic(topic)}
+}
+
+type topicPartitioner struct {
+	fallback kgo.TopicPartitioner
+}
+
+func (p *topicPartitioner) RequiresConsistency(r *kgo.Record) bool {
+	if _, ok := kafkaid.ParseKey(r.Key); ok {
+		return true
+	}
+	return p.fallback.RequiresConsistency(r)
+}
+
+func (p *topicPartitioner) Partition(r *kgo.Record, n int) int {
+	if i, ok := kafkaid.Partition(r.Key, n); ok {
+		return i
+	}
+	return p.fallback.Partition(r, n)
+}
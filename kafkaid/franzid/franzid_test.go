@@ -0,0 +1,26 @@
+package franzid
+
+import (
+	"testing"
+
+	"github.com/bradleypeabody/gouuidv6"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestPartitioner(t *testing.T) {
+
+	u := gouuidv6.New()
+	want := u.Partition(10)
+
+	p := Partitioner().ForTopic("t")
+	if got := p.Partition(&kgo.Record{Key: []byte(u.String())}, 10); got != want {
+		t.Fatalf("Expected partition %d, got %d", want, got)
+	}
+	if !p.RequiresConsistency(&kgo.Record{Key: u[:]}) {
+		t.Fatalf("Expected UUID keys to require consistency")
+	}
+	if got := p.Partition(&kgo.Record{Key: []byte("customer-42")}, 10); got < 0 || got >= 10 {
+		t.Fatalf("Expected fallback partition, got %d", got)
+	}
+
+}
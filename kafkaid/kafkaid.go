@@ -0,0 +1,37 @@
+// Package kafkaid partitions Kafka records keyed by "Version 6" UUIDs, using
+// gouuidv6.UUID.Partition.  Keys may be the 16 raw bytes of the UUID, its
+// standard hex form or its base64 form (see gouuidv6.UUIDB64); any 16 byte
+// key is taken to be a UUID.  Partitioners for the sarama and franz-go
+// clients are in the saramaid and franzid subpackages, which are separate
+// modules so this package does not depend on either client.
+package kafkaid
+
+import "github.com/bradleypeabody/gouuidv6"
+
+// Partition returns which of n partitions a record with key belongs to, and
+// false if key is not a UUID.
+func Partition(key []byte, n int) (int, bool) {
+	u, ok := ParseKey(key)
+	if !ok {
+		return 0, false
+	}
+	return u.Partition(n), true
+}
+
+// ParseKey returns the UUID in key as raw bytes, hex or base64, and false if
+// key is not a UUID.
+func ParseKey(key []byte) (gouuidv6.UUID, bool) {
+	var u gouuidv6.UUID
+	switch len(key) {
+	case 16:
+		copy(u[:], key)
+		return u, true
+	case 36:
+		return u, u.DecodeText(key) == nil
+	case 22:
+		var b gouuidv6.UUIDB64
+		err := b.DecodeText(key)
+		return gouuidv6.UUID(b), err == nil
+	}
+	return u, false
+}
@@ -0,0 +1,27 @@
+package kafkaid
+
+import (
+	"testing"
+
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestPartition(t *testing.T) {
+
+	u := gouuidv6.New()
+	want := u.Partition(10)
+
+	for _, key := range [][]byte{u[:], []byte(u.String()), []byte(gouuidv6.UUIDB64(u).String())} {
+		if got, ok := Partition(key, 10); !ok || got != want {
+			t.Fatalf("Expected partition %d for key %q, got %d (ok=%v)", want, key, got, ok)
+		}
+		if got, ok := ParseKey(key); !ok || got != u {
+			t.Fatalf("Expected %v from key %q, got %v (ok=%v)", u, key, got, ok)
+		}
+	}
+
+	if _, ok := Partition([]byte("customer-42"), 10); ok {
+		t.Fatalf("Expected non-UUID key not to be partitioned")
+	}
+
+}
@@ -0,0 +1,37 @@
+// Package saramaid provides a sarama partitioner for Kafka topics keyed by
+// "Version 6" UUIDs, using kafkaid.Partition.  Records with other keys, or
+// none, are partitioned by sarama's hash partitioner.
+//
+//	config.Producer.Partitioner = saramaid.NewPartitioner
+package saramaid
+
+import (
+	"github.com/IBM/sarama"
+	"github.com/bradleypeabody/gouuidv6/kafkaid"
+)
+
+// NewPartitioner is a sarama.PartitionerConstructor, for
+// sarama.Config.Producer.Partitioner, falling back to
+// sarama.NewHashPartitioner.
+func NewPartitioner(topic string) sarama.Partitioner {
+	return &partitioner{fallback: sarama.NewHashPartitioner(topic)}
+}
+
+type partitioner struct {
+	fallback sarama.Partitioner
+}
+
+func (p *partitioner) Partition(m *sarama.ProducerMessage, n int32) (int32, error) {
+	if m.Key != nil {
+		key, err := m.Key.Encode()
+		if err != nil {
+			return -1, err
+		}
+		if i, ok := kafkaid.Partition(key, int(n)); ok {
+			return int32(i), nil
+		}
+	}
+	return p.fallback.Partition(m, n)
+}
+
+func (p *partitioner) RequiresConsistency() bool { return true }
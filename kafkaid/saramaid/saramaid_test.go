@@ -0,0 +1,23 @@
+package saramaid
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestPartitioner(t *testing.T) {
+
+	u := gouuidv6.New()
+	want := u.Partition(10)
+
+	p := NewPartitioner("t")
+	if got, err := p.Partition(&sarama.ProducerMessage{Key: sarama.ByteEncoder(u[:])}, 10); err != nil || int(got) != want {
+		t.Fatalf("Expected partition %d, got %d (err=%v)", want, got, err)
+	}
+	if got, err := p.Partition(&sarama.ProducerMessage{Key: sarama.StringEncoder("customer-42")}, 10); err != nil || got < 0 || got >= 10 {
+		t.Fatalf("Expected fallback partition, got %d (err=%v)", got, err)
+	}
+
+}
@@ -0,0 +1,80 @@
+// Package promid exports the metrics of a gouuidv6.Generator (see
+// gouuidv6.GeneratorMetrics) to Prometheus.
+//
+//	prometheus.MustRegister(promid.NewCollector(nil, "myapp"))
+//
+// gives myapp_uuidv6_generated_total and so on for the package default
+// Generator.
+package promid
+
+import (
+	"github.com/bradleypeabody/gouuidv6"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus.Collector reading a Generator's metrics each
+// time it is scraped.
+type Collector struct {
+	metrics func() gouuidv6.GeneratorMetrics
+
+	generated, clockSeqIncrements, clockRegressions *prometheus.Desc
+	nodeRandomizations, entropyFailures, sinkErrors *prometheus.Desc
+	driftWarnings, clockDrift                       *prometheus.Desc
+}
+
+// NewCollector returns a Collector for g, or the package default Generator
+// if g is nil.  Metric names are prefixed with namespace, if it is not
+// empty.
+func NewCollector(g *gouuidv6.Generator, namespace string) *Collector {
+
+	metrics := gouuidv6.Metrics
+	if g != nil {
+		metrics = g.Metrics
+	}
+
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "uuidv6", name), help, nil, nil)
+	}
+
+	return &Collector{
+		metrics:            metrics,
+		generated:          desc("generated_total", "UUIDs generated."),
+		clockSeqIncrements: desc("clockseq_increments_total", "Times the clock sequence was incremented as the clock had not moved on."),
+		clockRegressions:   desc("clock_regressions_total", "Times the clock was found to have moved backward."),
+		nodeRandomizations: desc("node_randomizations_total", "Random nodes generated."),
+		entropyFailures:    desc("entropy_failures_total", "Failed reads of random data."),
+		sinkErrors:         desc("sink_errors_total", "Failed writes to a sink."),
+		driftWarnings:      desc("drift_warnings_total", "Clock drift reports beyond the threshold."),
+		clockDrift:         desc("clock_drift_seconds", "Last clock drift reported."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.generated, c.clockSeqIncrements, c.clockRegressions,
+		c.nodeRandomizations, c.entropyFailures, c.sinkErrors,
+		c.driftWarnings, c.clockDrift,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+
+	m := c.metrics()
+
+	counter := func(d *prometheus.Desc, v uint64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, float64(v))
+	}
+	counter(c.generated, m.Generated)
+	counter(c.clockSeqIncrements, m.ClockSeqIncrements)
+	counter(c.clockRegressions, m.ClockRegressions)
+	counter(c.nodeRandomizations, m.NodeRandomizations)
+	counter(c.entropyFailures, m.EntropyFailures)
+	counter(c.sinkErrors, m.SinkErrors)
+	counter(c.driftWarnings, m.DriftWarnings)
+
+	ch <- prometheus.MustNewConstMetric(c.clockDrift, prometheus.GaugeValue, float64(m.ClockDrift)/1e9)
+}
@@ -0,0 +1,49 @@
+package promid
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bradleypeabody/gouuidv6"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+
+	tim := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := gouuidv6.NewGenerator()
+	g.NewFromTime(tim)
+	g.NewFromTime(tim)
+	g.NewFromTime(tim.Add(-time.Second))
+	g.ReportDrift(-1500 * time.Millisecond)
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(NewCollector(g, "test"))
+
+	want := `
+# HELP test_uuidv6_generated_total UUIDs generated.
+# TYPE test_uuidv6_generated_total counter
+test_uuidv6_generated_total 3
+# HELP test_uuidv6_clockseq_increments_total Times the clock sequence was incremented as the clock had not moved on.
+# TYPE test_uuidv6_clockseq_increments_total counter
+test_uuidv6_clockseq_increments_total 2
+# HELP test_uuidv6_clock_regressions_total Times the clock was found to have moved backward.
+# TYPE test_uuidv6_clock_regressions_total counter
+test_uuidv6_clock_regressions_total 1
+# HELP test_uuidv6_clock_drift_seconds Last clock drift reported.
+# TYPE test_uuidv6_clock_drift_seconds gauge
+test_uuidv6_clock_drift_seconds -1.5
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"test_uuidv6_generated_total", "test_uuidv6_clockseq_increments_total",
+		"test_uuidv6_clock_regressions_total", "test_uuidv6_clock_drift_seconds"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := testutil.CollectAndCount(NewCollector(nil, "")); n != 8 {
+		t.Fatalf("Expected 8 metrics, got %d", n)
+	}
+
+}
@@ -0,0 +1,84 @@
+// Package spannerid lets "Version 6" UUIDs be written to and read from Google
+// Cloud Spanner columns directly, in mutations, statement parameters and
+// with Row.Column and Row.ToStruct, by implementing spanner.Encoder and
+// spanner.Decoder.  Bytes stores a UUID in a BYTES(16) column and String in
+// a STRING(36) column; either reads from both kinds of column.  Both sort by
+// time, but as a primary key that makes Spanner write every new row to the
+// same split, so keys are best prefixed by a shard or hash of something
+// else, or stored in an interleaved table.
+//
+// A NULL column decodes as an error, as with Spanner's own types; scan into
+// a *Bytes or *String to allow NULLs.
+package spannerid
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// Bytes is a UUID stored in a BYTES(16) column.
+type Bytes gouuidv6.UUID
+
+// String is a UUID stored in a STRING(36) column in the usual text form.
+type String gouuidv6.UUID
+
+var (
+	_ spanner.Encoder = Bytes{}
+	_ spanner.Decoder = (*Bytes)(nil)
+	_ spanner.Encoder = String{}
+	_ spanner.Decoder = (*String)(nil)
+)
+
+func (u Bytes) EncodeSpanner() (interface{}, error) { return u[:], nil }
+
+func (u *Bytes) DecodeSpanner(input interface{}) error {
+	v, err := decode(input)
+	*u = Bytes(v)
+	return err
+}
+
+// Return the UUID in the usual text form.
+func (u Bytes) String() string { return gouuidv6.UUID(u).String() }
+
+func (u String) EncodeSpanner() (interface{}, error) { return gouuidv6.UUID(u).String(), nil }
+
+func (u *String) DecodeSpanner(input interface{}) error {
+	v, err := decode(input)
+	*u = String(v)
+	return err
+}
+
+// Return the UUID in the usual text form.
+func (u String) String() string { return gouuidv6.UUID(u).String() }
+
+// Return the UUID from a column value as the Spanner client gives it to a
+// Decoder: the text of a STRING column, or the base64 of a BYTES column.
+func decode(input interface{}) (gouuidv6.UUID, error) {
+	var u gouuidv6.UUID
+	switch v := input.(type) {
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return u, nil
+		}
+		return u, fmt.Errorf("spannerid: %d bytes is not a UUID", len(v))
+	case *string:
+		if v == nil {
+			return u, fmt.Errorf("spannerid: cannot decode NULL as a UUID")
+		}
+		return decode(*v)
+	case string:
+		if len(v) == 24 {
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err == nil && len(b) == 16 {
+				copy(u[:], b)
+				return u, nil
+			}
+		}
+		return gouuidv6.Parse(v)
+	}
+	return u, fmt.Errorf("spannerid: cannot decode %T as a UUID", input)
+}
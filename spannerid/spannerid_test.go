@@ -0,0 +1,73 @@
+package spannerid
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestRoundTrip(t *testing.T) {
+
+	u := gouuidv6.New()
+
+	// NewRow encodes the values as they would be sent in a mutation
+	row, err := spanner.NewRow([]string{"b", "s"}, []interface{}{Bytes(u), String(u)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b, b2 Bytes
+	var s, s2 String
+	if err := row.Columns(&b, &s); err != nil {
+		t.Fatal(err)
+	}
+	if gouuidv6.UUID(b) != u || gouuidv6.UUID(s) != u {
+		t.Fatalf("Expected %v from both columns, got %v and %v", u, b, s)
+	}
+
+	// either type reads either kind of column
+	if err := row.Columns(&s2, &b2); err != nil {
+		t.Fatal(err)
+	}
+	if gouuidv6.UUID(b2) != u || gouuidv6.UUID(s2) != u {
+		t.Fatalf("Expected %v from the other columns, got %v and %v", u, s2, b2)
+	}
+
+	var st struct {
+		B Bytes  `spanner:"b"`
+		S String `spanner:"s"`
+	}
+	if err := row.ToStruct(&st); err != nil {
+		t.Fatal(err)
+	}
+	if gouuidv6.UUID(st.B) != u || gouuidv6.UUID(st.S) != u {
+		t.Fatalf("Expected %v from ToStruct, got %+v", u, st)
+	}
+}
+
+func TestNull(t *testing.T) {
+
+	row, err := spanner.NewRow([]string{"s"}, []interface{}{spanner.NullString{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s String
+	if err := row.Column(0, &s); err == nil {
+		t.Fatalf("Expected error decoding NULL")
+	}
+	var p *String = &s
+	if err := row.Column(0, &p); err != nil || p != nil {
+		t.Fatalf("Expected nil pointer for NULL, got %v (%v)", p, err)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+
+	var b Bytes
+	for _, in := range []interface{}{"not a uuid", []byte{1, 2, 3}, 1.5} {
+		if err := b.DecodeSpanner(in); err == nil {
+			t.Fatalf("Expected error decoding %#v", in)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/bradleypeabody/gouuidv6/thriftid
+
+go 1.25
+
+require (
+	github.com/apache/thrift v0.24.0
+	github.com/bradleypeabody/gouuidv6 v0.0.0-00010101000000-000000000000
+)
+
+replace github.com/bradleypeabody/gouuidv6 => ../
@@ -0,0 +1,92 @@
+// Package thriftid reads and writes "Version 6" UUIDs with Apache Thrift
+// protocols (binary, compact or any other thrift.TProtocol), for hand
+// written or adapted serialization code where the generated code's field
+// types are not gouuidv6.UUID.  The IDL can declare the field in any of the
+// three ways Encoding covers:
+//
+//	1: binary id   // Binary: the 16 bytes
+//	2: string id   // String: the usual 36 character text form
+//	3: uuid id     // UUID: Thrift's own uuid type (Thrift 0.19 and later)
+package thriftid
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+// Encoding is how a UUID field is declared in the IDL.
+type Encoding int
+
+const (
+	// Binary is a binary field holding the 16 bytes of the UUID.
+	Binary Encoding = iota
+	// String is a string field holding the text form of the UUID.
+	String
+	// UUID is a field of Thrift's uuid type.
+	UUID
+)
+
+// TType returns the Thrift type of fields with the encoding, for
+// WriteFieldBegin and for checking the type of a field being read.
+func (e Encoding) TType() thrift.TType {
+	if e == UUID {
+		return thrift.UUID
+	}
+	return thrift.STRING
+}
+
+// Write writes u to p with the encoding e.
+func Write(ctx context.Context, p thrift.TProtocol, u gouuidv6.UUID, e Encoding) error {
+	switch e {
+	case Binary:
+		return p.WriteBinary(ctx, u[:])
+	case String:
+		return p.WriteString(ctx, u.String())
+	case UUID:
+		return p.WriteUUID(ctx, thrift.Tuuid(u))
+	}
+	return fmt.Errorf("thriftid: unknown encoding %d", e)
+}
+
+// WriteField writes u to p as the field with name and id, including the
+// field header.
+func WriteField(ctx context.Context, p thrift.TProtocol, name string, id int16, u gouuidv6.UUID, e Encoding) error {
+	if err := p.WriteFieldBegin(ctx, name, e.TType(), id); err != nil {
+		return err
+	}
+	if err := Write(ctx, p, u, e); err != nil {
+		return err
+	}
+	return p.WriteFieldEnd(ctx)
+}
+
+// Read reads a UUID written with the encoding e from p.  String fields may
+// hold any of the forms gouuidv6.Parse accepts.
+func Read(ctx context.Context, p thrift.TProtocol, e Encoding) (gouuidv6.UUID, error) {
+	var u gouuidv6.UUID
+	switch e {
+	case Binary:
+		b, err := p.ReadBinary(ctx)
+		if err != nil {
+			return u, err
+		}
+		if len(b) != 16 {
+			return u, fmt.Errorf("thriftid: binary field of %d bytes is not a UUID", len(b))
+		}
+		copy(u[:], b)
+		return u, nil
+	case String:
+		s, err := p.ReadString(ctx)
+		if err != nil {
+			return u, err
+		}
+		return gouuidv6.Parse(s)
+	case UUID:
+		t, err := p.ReadUUID(ctx)
+		return gouuidv6.UUID(t), err
+	}
+	return u, fmt.Errorf("thriftid: unknown encoding %d", e)
+}
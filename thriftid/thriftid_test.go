@@ -0,0 +1,85 @@
+package thriftid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/bradleypeabody/gouuidv6"
+)
+
+func TestRoundTrip(t *testing.T) {
+
+	ctx := context.Background()
+	u := gouuidv6.New()
+
+	protocols := map[string]func(thrift.TTransport) thrift.TProtocol{
+		"binary": func(tr thrift.TTransport) thrift.TProtocol {
+			return thrift.NewTBinaryProtocolConf(tr, nil)
+		},
+		"compact": func(tr thrift.TTransport) thrift.TProtocol {
+			return thrift.NewTCompactProtocolConf(tr, nil)
+		},
+	}
+
+	for name, newProtocol := range protocols {
+		for _, e := range []Encoding{Binary, String, UUID} {
+
+			buf := thrift.NewTMemoryBuffer()
+			p := newProtocol(buf)
+
+			if err := p.WriteStructBegin(ctx, "Order"); err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteField(ctx, p, "id", 1, u, e); err != nil {
+				t.Fatalf("%s %d: %v", name, e, err)
+			}
+			if err := p.WriteFieldStop(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if err := p.WriteStructEnd(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := p.ReadStructBegin(ctx); err != nil {
+				t.Fatal(err)
+			}
+			_, typ, id, err := p.ReadFieldBegin(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if typ != e.TType() || id != 1 {
+				t.Fatalf("%s %d: Expected field 1 of type %v, got field %d of type %v", name, e, e.TType(), id, typ)
+			}
+			u2, err := Read(ctx, p, e)
+			if err != nil || u2 != u {
+				t.Fatalf("%s %d: Expected %v, got %v (%v)", name, e, u, u2, err)
+			}
+		}
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+
+	ctx := context.Background()
+
+	buf := thrift.NewTMemoryBuffer()
+	p := thrift.NewTCompactProtocolConf(buf, nil)
+	if err := p.WriteBinary(ctx, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(ctx, p, Binary); err == nil {
+		t.Fatalf("Expected error reading 3 bytes")
+	}
+
+	if err := p.WriteString(ctx, "not a uuid"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(ctx, p, String); err == nil {
+		t.Fatalf("Expected error reading an invalid string")
+	}
+
+	if err := Write(ctx, p, gouuidv6.New(), Encoding(9)); err == nil {
+		t.Fatalf("Expected error for an unknown encoding")
+	}
+}